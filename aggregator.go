@@ -0,0 +1,238 @@
+package healthcheck
+
+// Aggregator calculates the aggregated status from the results of probes.
+type Aggregator interface {
+	// Aggregate returns the aggregated status of the given probe results by probe name.
+	// StatusUnknown is expected if there are no results with a known status.
+	Aggregate(results map[string]ProbeResult) Status
+}
+
+// AggregatorFunc is a function implementing Aggregator.
+type AggregatorFunc func(results map[string]ProbeResult) Status
+
+// Aggregate calls the function itself.
+func (f AggregatorFunc) Aggregate(results map[string]ProbeResult) Status {
+	return f(results)
+}
+
+// WorstWins returns an Aggregator reporting the worst status among the results.
+// This is the default aggregation strategy.
+func WorstWins() Aggregator {
+	return AggregatorFunc(
+		func(results map[string]ProbeResult) Status {
+			status := StatusUnknown
+			for _, r := range results {
+				impact := r.Impact()
+				if impact.severity() <= status.severity() {
+					continue
+				}
+
+				status = impact
+				if status == StatusUnhealthy {
+					break
+				}
+			}
+			return status
+		},
+	)
+}
+
+// Majority returns an Aggregator reporting
+// healthy if more than half of the results are healthy,
+// unhealthy if more than half of the results are unhealthy
+// and degraded otherwise.
+// Results with an unknown or starting status are not counted,
+// yet starting results turn an unhealthy outcome into starting.
+func Majority() Aggregator {
+	return AggregatorFunc(
+		func(results map[string]ProbeResult) Status {
+			counts := countStatuses(results)
+
+			total := counts[StatusHealthy] +
+				counts[StatusDegraded] +
+				counts[StatusUnhealthy]
+
+			switch {
+			case total == 0:
+				return starting(StatusUnknown, counts)
+			case counts[StatusHealthy]*2 > total:
+				return StatusHealthy
+			case counts[StatusUnhealthy]*2 > total:
+				return starting(StatusUnhealthy, counts)
+			default:
+				return StatusDegraded
+			}
+		},
+	)
+}
+
+// Quorum returns an Aggregator reporting
+// healthy if at least k results are healthy,
+// degraded if at least k results are either healthy or degraded
+// and unhealthy otherwise.
+// If names are given, the quorum only counts the probes with these names,
+// such as the replicas of a service,
+// while the other probes are aggregated as by WorstWins
+// and the worse of both statuses is reported.
+// Otherwise, the quorum counts all probes.
+// Results with an unknown or starting status are not counted,
+// yet starting results turn an unhealthy outcome into starting.
+// If k is less than 1, the aggregator is invalid and WithAggregator fails.
+func Quorum(k int, names ...string) Aggregator {
+	if k < 1 {
+		return failedAggregator{
+			err: invalidOption("healthcheck quorum must be greater than zero"),
+		}
+	}
+
+	members := map[string]struct{}{}
+	for _, name := range names {
+		members[name] = struct{}{}
+	}
+
+	quorum := func(results map[string]ProbeResult) Status {
+		counts := countStatuses(results)
+
+		switch {
+		case counts[StatusHealthy]+
+			counts[StatusDegraded]+
+			counts[StatusUnhealthy] == 0:
+			return starting(StatusUnknown, counts)
+		case counts[StatusHealthy] >= k:
+			return StatusHealthy
+		case counts[StatusHealthy]+counts[StatusDegraded] >= k:
+			return StatusDegraded
+		default:
+			return starting(StatusUnhealthy, counts)
+		}
+	}
+
+	if len(members) == 0 {
+		return AggregatorFunc(quorum)
+	}
+
+	worstWins := WorstWins()
+
+	return AggregatorFunc(
+		func(results map[string]ProbeResult) Status {
+			counted := map[string]ProbeResult{}
+			others := map[string]ProbeResult{}
+			for name, r := range results {
+				if _, ok := members[name]; ok {
+					counted[name] = r
+				} else {
+					others[name] = r
+				}
+			}
+
+			return worse(quorum(counted), worstWins.Aggregate(others))
+		},
+	)
+}
+
+// Weighted returns an Aggregator scoring the results
+// as a weighted average of 1 for healthy, 0.5 for degraded and 0 for unhealthy.
+// The aggregated status is healthy if the score is at least healthyScore,
+// degraded if the score is at least degradedScore and unhealthy otherwise.
+// Probes missing from weights have a weight of 1.
+// Results with an unknown or starting status are not counted,
+// yet starting results turn an unhealthy outcome into starting.
+// If any weight is negative
+// or scores are not within (0, 1] with degradedScore not greater than healthyScore,
+// the aggregator is invalid and WithAggregator fails.
+func Weighted(
+	weights map[string]float64, healthyScore, degradedScore float64,
+) Aggregator {
+	for _, w := range weights {
+		if w < 0 {
+			return failedAggregator{
+				err: invalidOption("healthcheck weight cannot be negative"),
+			}
+		}
+	}
+
+	if healthyScore <= 0 || healthyScore > 1 ||
+		degradedScore <= 0 || degradedScore > healthyScore {
+		return failedAggregator{
+			err: invalidOption("healthcheck weighted scores are invalid"),
+		}
+	}
+
+	return AggregatorFunc(
+		func(results map[string]ProbeResult) Status {
+			counts := countStatuses(results)
+
+			var score, total float64
+			for name, r := range results {
+				weight, ok := weights[name]
+				if !ok {
+					weight = 1
+				}
+
+				switch r.Impact() {
+				case StatusHealthy:
+					score += weight
+				case StatusDegraded:
+					score += weight / 2
+				case StatusUnhealthy:
+				default:
+					continue
+				}
+
+				total += weight
+			}
+
+			switch {
+			case total == 0:
+				return starting(StatusUnknown, counts)
+			case score/total >= healthyScore:
+				return StatusHealthy
+			case score/total >= degradedScore:
+				return StatusDegraded
+			default:
+				return starting(StatusUnhealthy, counts)
+			}
+		},
+	)
+}
+
+// failedAggregator is an Aggregator built from invalid arguments.
+// WithAggregator fails with its error,
+// so that NewE reports it instead of panicking.
+type failedAggregator struct {
+	err error
+}
+
+// Aggregate panics with the error message,
+// since an invalid aggregator cannot aggregate.
+func (a failedAggregator) Aggregate(map[string]ProbeResult) Status {
+	panic(a.err.Error())
+}
+
+// worse returns the more severe of the two statuses.
+func worse(a, b Status) Status {
+	if b.severity() > a.severity() {
+		return b
+	}
+
+	return a
+}
+
+func countStatuses(results map[string]ProbeResult) map[Status]int {
+	counts := map[Status]int{}
+	for _, r := range results {
+		counts[r.Impact()]++
+	}
+
+	return counts
+}
+
+// starting returns StatusStarting if any of the results is starting
+// and the given status otherwise.
+func starting(status Status, counts map[Status]int) Status {
+	if counts[StatusStarting] > 0 {
+		return StatusStarting
+	}
+
+	return status
+}
@@ -0,0 +1,300 @@
+package healthcheck
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func resultsOf(statuses ...Status) map[string]ProbeResult {
+	names := []string{"p1", "p2", "p3", "p4", "p5"}
+
+	results := map[string]ProbeResult{}
+	for i, s := range statuses {
+		results[names[i]] = ProbeResult{Status: s, Critical: true}
+	}
+
+	return results
+}
+
+func TestWorstWins(t *testing.T) {
+	t.Parallel()
+
+	a := WorstWins()
+
+	assert.Equal(t, StatusUnknown, a.Aggregate(resultsOf()))
+	assert.Equal(t, StatusUnknown, a.Aggregate(resultsOf(StatusUnknown)))
+	assert.Equal(
+		t, StatusHealthy, a.Aggregate(resultsOf(StatusHealthy, StatusUnknown)),
+	)
+	assert.Equal(
+		t, StatusDegraded, a.Aggregate(resultsOf(StatusHealthy, StatusDegraded)),
+	)
+	assert.Equal(
+		t, StatusUnhealthy,
+		a.Aggregate(resultsOf(StatusDegraded, StatusUnhealthy, StatusHealthy)),
+	)
+
+	assert.Equal(
+		t, StatusStarting,
+		a.Aggregate(resultsOf(StatusDegraded, StatusStarting, StatusHealthy)),
+	)
+	assert.Equal(
+		t, StatusUnhealthy,
+		a.Aggregate(resultsOf(StatusStarting, StatusUnhealthy)),
+	)
+
+	nonCritical := map[string]ProbeResult{
+		"p1": {Status: StatusHealthy, Critical: true},
+		"p2": {Status: StatusUnhealthy, Critical: false},
+	}
+	assert.Equal(t, StatusDegraded, a.Aggregate(nonCritical))
+}
+
+func TestMajority(t *testing.T) {
+	t.Parallel()
+
+	a := Majority()
+
+	tests := map[string]struct {
+		results map[string]ProbeResult
+		want    Status
+	}{
+		"empty": {
+			results: resultsOf(),
+			want:    StatusUnknown,
+		},
+		"unknown": {
+			results: resultsOf(StatusUnknown, StatusUnknown),
+			want:    StatusUnknown,
+		},
+		"healthy_majority": {
+			results: resultsOf(StatusHealthy, StatusHealthy, StatusUnhealthy),
+			want:    StatusHealthy,
+		},
+		"unhealthy_majority": {
+			results: resultsOf(StatusUnhealthy, StatusUnhealthy, StatusHealthy),
+			want:    StatusUnhealthy,
+		},
+		"no_majority": {
+			results: resultsOf(StatusHealthy, StatusDegraded, StatusUnhealthy),
+			want:    StatusDegraded,
+		},
+		"tie": {
+			results: resultsOf(StatusHealthy, StatusUnhealthy),
+			want:    StatusDegraded,
+		},
+		"unknown_not_counted": {
+			results: resultsOf(StatusHealthy, StatusUnknown, StatusUnknown),
+			want:    StatusHealthy,
+		},
+		"starting": {
+			results: resultsOf(StatusStarting, StatusStarting),
+			want:    StatusStarting,
+		},
+		"unhealthy_majority_starting": {
+			results: resultsOf(
+				StatusUnhealthy, StatusUnhealthy, StatusStarting,
+			),
+			want: StatusStarting,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+				assert.Equal(t, tt.want, a.Aggregate(tt.results))
+			},
+		)
+	}
+}
+
+func TestQuorum(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(
+		t, "healthcheck quorum must be greater than zero",
+		func() {
+			New(WithAggregator(Quorum(0)))
+		},
+	)
+
+	_, err := NewE(WithAggregator(Quorum(0)))
+	assert.ErrorIs(t, err, ErrInvalidOption)
+
+	a := Quorum(2)
+
+	tests := map[string]struct {
+		results map[string]ProbeResult
+		want    Status
+	}{
+		"empty": {
+			results: resultsOf(),
+			want:    StatusUnknown,
+		},
+		"one_of_three_down": {
+			results: resultsOf(StatusHealthy, StatusHealthy, StatusUnhealthy),
+			want:    StatusHealthy,
+		},
+		"degraded_quorum": {
+			results: resultsOf(StatusHealthy, StatusDegraded, StatusUnhealthy),
+			want:    StatusDegraded,
+		},
+		"two_of_three_down": {
+			results: resultsOf(StatusHealthy, StatusUnhealthy, StatusUnhealthy),
+			want:    StatusUnhealthy,
+		},
+		"two_of_three_starting": {
+			results: resultsOf(StatusHealthy, StatusStarting, StatusStarting),
+			want:    StatusStarting,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+				assert.Equal(t, tt.want, a.Aggregate(tt.results))
+			},
+		)
+	}
+}
+
+func TestQuorum_Names(t *testing.T) {
+	t.Parallel()
+
+	a := Quorum(2, "redis-1", "redis-2", "redis-3")
+
+	results := func(database Status, replicas ...Status) map[string]ProbeResult {
+		results := map[string]ProbeResult{
+			"database": {Status: database, Critical: true},
+		}
+		for i, s := range replicas {
+			results[fmt.Sprintf("redis-%d", i+1)] = ProbeResult{
+				Status: s, Critical: true,
+			}
+		}
+
+		return results
+	}
+
+	tests := map[string]struct {
+		results map[string]ProbeResult
+		want    Status
+	}{
+		"one_replica_down": {
+			results: results(
+				StatusHealthy,
+				StatusHealthy, StatusHealthy, StatusUnhealthy,
+			),
+			want: StatusHealthy,
+		},
+		"database_down": {
+			results: results(
+				StatusUnhealthy,
+				StatusHealthy, StatusHealthy, StatusHealthy,
+			),
+			want: StatusUnhealthy,
+		},
+		"database_degraded": {
+			results: results(
+				StatusDegraded,
+				StatusHealthy, StatusHealthy, StatusUnhealthy,
+			),
+			want: StatusDegraded,
+		},
+		"two_replicas_down": {
+			results: results(
+				StatusHealthy,
+				StatusHealthy, StatusUnhealthy, StatusUnhealthy,
+			),
+			want: StatusUnhealthy,
+		},
+		"replicas_starting": {
+			results: results(
+				StatusHealthy,
+				StatusStarting, StatusStarting, StatusHealthy,
+			),
+			want: StatusStarting,
+		},
+		"no_replicas": {
+			results: results(StatusDegraded),
+			want:    StatusDegraded,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+				assert.Equal(t, tt.want, a.Aggregate(tt.results))
+			},
+		)
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(
+		t, "healthcheck weight cannot be negative",
+		func() {
+			New(WithAggregator(Weighted(map[string]float64{"p1": -1}, 1, 0.5)))
+		},
+	)
+
+	for _, scores := range [][2]float64{{0, 0}, {1.5, 0.5}, {0.5, 0.8}, {1, 0}} {
+		assert.PanicsWithValue(
+			t, "healthcheck weighted scores are invalid",
+			func() {
+				New(WithAggregator(Weighted(nil, scores[0], scores[1])))
+			},
+		)
+
+		_, err := NewE(WithAggregator(Weighted(nil, scores[0], scores[1])))
+		assert.ErrorIs(t, err, ErrInvalidOption)
+	}
+
+	a := Weighted(map[string]float64{"p1": 3}, 0.75, 0.5)
+
+	tests := map[string]struct {
+		results map[string]ProbeResult
+		want    Status
+	}{
+		"empty": {
+			results: resultsOf(),
+			want:    StatusUnknown,
+		},
+		"heavy_healthy": {
+			results: resultsOf(StatusHealthy, StatusUnhealthy),
+			want:    StatusHealthy,
+		},
+		"heavy_degraded": {
+			results: resultsOf(StatusDegraded, StatusHealthy),
+			want:    StatusDegraded,
+		},
+		"heavy_unhealthy": {
+			results: resultsOf(StatusUnhealthy, StatusHealthy),
+			want:    StatusUnhealthy,
+		},
+		"unknown_not_counted": {
+			results: resultsOf(StatusUnknown, StatusHealthy),
+			want:    StatusHealthy,
+		},
+		"heavy_starting": {
+			results: resultsOf(StatusStarting, StatusUnhealthy),
+			want:    StatusStarting,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+				assert.Equal(t, tt.want, a.Aggregate(tt.results))
+			},
+		)
+	}
+}
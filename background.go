@@ -0,0 +1,120 @@
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Start launches background probing.
+// Every probe is run immediately and then periodically on its own interval
+// in a separate goroutine, while Handle and its variants
+// return the last known results instead of running the probes.
+// Probes that have not completed yet are reported with StatusUnknown,
+// and until every critical probe has completed,
+// the aggregated status is reported as StatusStarting unless it is unhealthy.
+// Calling Start on a running Healthcheck has no effect.
+func (hc *Healthcheck) Start() {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if hc.running {
+		return
+	}
+
+	hc.runCtx, hc.cancel = context.WithCancel(context.Background())
+	hc.workers = &sync.WaitGroup{}
+	hc.running = true
+
+	hc.probesMu.RLock()
+	defer hc.probesMu.RUnlock()
+
+	for name, r := range hc.probes {
+		hc.startProbe(name, r)
+	}
+}
+
+// Stop terminates background probing and waits for all probe goroutines to exit.
+// After Stop, Handle and its variants run the probes synchronously again,
+// including while the probe goroutines are exiting.
+// Calling Stop on a Healthcheck that is not running has no effect.
+func (hc *Healthcheck) Stop() {
+	workers := hc.stop()
+	if workers != nil {
+		workers.Wait()
+	}
+}
+
+// stop cancels background probing and returns the probe goroutines to wait for,
+// or nil if the Healthcheck is not running.
+// The goroutines are waited for without the lifecycle mutex held,
+// so that health checks are not blocked by probes ignoring their context.
+func (hc *Healthcheck) stop() *sync.WaitGroup {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if !hc.running {
+		return nil
+	}
+
+	hc.cancel()
+	workers := hc.workers
+
+	hc.runCtx, hc.cancel, hc.workers = nil, nil, nil
+	hc.running = false
+
+	return workers
+}
+
+func (hc *Healthcheck) isRunning() bool {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	return hc.running
+}
+
+// startProbe launches background probing of a single probe.
+// Must be called with the lifecycle mutex held while running.
+func (hc *Healthcheck) startProbe(name string, r *registration) {
+	ctx, cancel := context.WithCancel(hc.runCtx)
+	r.cancel = cancel
+
+	workers := hc.workers
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		hc.runProbe(ctx, name, r)
+	}()
+}
+
+func (hc *Healthcheck) runProbe(ctx context.Context, name string, r *registration) {
+
+	interval := r.interval
+	if interval == 0 {
+		interval = hc.interval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger := hc.logger.With("probe", name)
+
+	for {
+		result := hc.probeCheck(logger, ctx, r)
+
+		// The probe has been interrupted by Stop or Unregister,
+		// so its result does not reflect the health of the dependency.
+		if ctx.Err() != nil {
+			return
+		}
+
+		hc.record(name, r, result)
+		hc.observe(hc.applyOverride(hc.cachedReport("", hc.selectProbes(""))))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
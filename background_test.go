@@ -0,0 +1,269 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthcheck_Start(t *testing.T) {
+	t.Parallel()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	fastCalls := atomic.Int32{}
+	slowCalls := atomic.Int32{}
+	release := make(chan struct{})
+
+	hc := New(
+		WithInterval(time.Hour),
+		WithSimpleProbe(
+			"fast", func(_ context.Context) error {
+				fastCalls.Add(1)
+				return nil
+			},
+			ProbeInterval(5*time.Millisecond),
+		),
+		WithSimpleProbe(
+			"slow", func(_ context.Context) error {
+				slowCalls.Add(1)
+				<-release
+				return errors.New("slow error")
+			},
+		),
+	)
+
+	hc.Start()
+	hc.Start()
+
+	assert.Eventually(
+		t, func() bool {
+			return fastCalls.Load() >= 3
+		}, time.Second, time.Millisecond,
+	)
+
+	report := hc.HandleReport(context.Background())
+	assert.Equal(t, StatusStarting, report.Status)
+	assert.Equal(t, StatusHealthy, report.Probes["fast"].Status)
+	assert.Equal(t, StatusUnknown, report.Probes["slow"].Status)
+
+	close(release)
+
+	assert.Eventually(
+		t, func() bool {
+			return hc.Handle(context.Background()) == StatusUnhealthy
+		}, time.Second, time.Millisecond,
+	)
+	assert.Equal(t, int32(1), slowCalls.Load())
+
+	hc.Stop()
+	hc.Stop()
+
+	calls := fastCalls.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, calls, fastCalls.Load())
+
+	assert.Equal(t, StatusUnhealthy, hc.Handle(context.Background()))
+	assert.Equal(t, calls+1, fastCalls.Load())
+	assert.Equal(t, int32(2), slowCalls.Load())
+}
+
+func TestHealthcheck_Start_Pending(t *testing.T) {
+	t.Parallel()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tests := map[string]struct {
+		err  error
+		opts []ProbeOption
+		want Status
+	}{
+		"healthy": {
+			want: StatusStarting,
+		},
+		"unhealthy": {
+			err:  errors.New("connection refused"),
+			want: StatusUnhealthy,
+		},
+		"non_critical": {
+			opts: []ProbeOption{ProbeNonCritical()},
+			want: StatusHealthy,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				hc := New(
+					WithSimpleProbe(
+						"fast", func(_ context.Context) error {
+							return tt.err
+						},
+					),
+					WithSimpleProbe(
+						"db", func(ctx context.Context) error {
+							<-ctx.Done()
+							return ctx.Err()
+						},
+						tt.opts...,
+					),
+				)
+
+				hc.Start()
+				defer hc.Stop()
+
+				ctx := context.Background()
+
+				assert.Eventually(
+					t, func() bool {
+						return hc.HandleReport(ctx).Probes["fast"].Status !=
+							StatusUnknown
+					}, time.Second, time.Millisecond,
+				)
+
+				report := hc.HandleReport(ctx)
+				assert.Equal(t, tt.want, report.Status)
+				assert.Equal(t, StatusUnknown, report.Probes["db"].Status)
+			},
+		)
+	}
+}
+
+func TestHealthcheck_Start_NoProbes(t *testing.T) {
+	t.Parallel()
+
+	hc := New()
+	hc.Start()
+	defer hc.Stop()
+
+	assert.Equal(t, StatusUnknown, hc.Handle(context.Background()))
+}
+
+func TestHealthcheck_Start_Register(t *testing.T) {
+	t.Parallel()
+
+	calls := atomic.Int32{}
+
+	hc := New(WithInterval(5 * time.Millisecond))
+	hc.Start()
+	defer hc.Stop()
+
+	err := hc.Register(
+		"probe", &probe{
+			check: func(_ context.Context) error {
+				calls.Add(1)
+				return nil
+			},
+		},
+	)
+	assert.NoError(t, err)
+
+	assert.Eventually(
+		t, func() bool {
+			return hc.Handle(context.Background()) == StatusHealthy
+		}, time.Second, time.Millisecond,
+	)
+
+	hc.Unregister("probe")
+
+	assert.Eventually(
+		t, func() bool {
+			c := calls.Load()
+			time.Sleep(20 * time.Millisecond)
+			return c == calls.Load()
+		}, time.Second, time.Millisecond,
+	)
+	assert.Equal(t, StatusUnknown, hc.Handle(context.Background()))
+}
+
+func TestHealthcheck_Stop_InFlight(t *testing.T) {
+	t.Parallel()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	started := make(chan struct{})
+	notified := atomic.Int32{}
+
+	hc := New(
+		WithHistorySize(10),
+		WithStatusChangeListener(
+			func(_, _ Status, _ Report) {
+				notified.Add(1)
+			},
+		),
+		WithProbeStatusChangeListener(
+			func(_ string, _, _ Status, _ ProbeResult) {
+				notified.Add(1)
+			},
+		),
+		WithSimpleProbe(
+			"probe", func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		),
+	)
+
+	hc.Start()
+	<-started
+	hc.Stop()
+
+	time.Sleep(20 * time.Millisecond)
+
+	assert.Empty(t, hc.History("probe"))
+	assert.Zero(t, notified.Load())
+	assert.Nil(t, hc.probes["probe"].last)
+	assert.Zero(t, hc.probes["probe"].failures)
+}
+
+func TestHealthcheck_Stop_IgnoringContext(t *testing.T) {
+	t.Parallel()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+
+	hc := New(
+		WithTimeoutUnhealthy(time.Minute),
+		WithSimpleProbe(
+			"probe", func(_ context.Context) error {
+				select {
+				case started <- struct{}{}:
+				default:
+				}
+				<-release
+				return nil
+			},
+		),
+	)
+
+	hc.Start()
+	<-started
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		hc.Stop()
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop is blocked by the probe ignoring its context")
+	}
+
+	report := hc.HandleReport(context.Background())
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.ErrorIs(t, report.Probes["probe"].Error, ErrProbeStuck)
+}
@@ -0,0 +1,107 @@
+package healthcheck
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNilProbe is returned when a nil probe is provided.
+	ErrNilProbe = errors.New("healthcheck probe cannot be nil")
+
+	// ErrProbeAlreadyRegistered is returned
+	// when a probe with the same name is already registered.
+	ErrProbeAlreadyRegistered = errors.New("healthcheck probe already registered")
+
+	// ErrProbeDeadlineExceeded is reported when a probe does not return
+	// within a grace period after its unhealthy timeout.
+	ErrProbeDeadlineExceeded = errors.New("healthcheck probe deadline exceeded")
+
+	// ErrInvalidStatus is returned when a status cannot be parsed or encoded.
+	ErrInvalidStatus = errors.New("healthcheck status is invalid")
+
+	// ErrInvalidOption is returned by NewE when an option is invalid.
+	ErrInvalidOption = errors.New("healthcheck option is invalid")
+
+	// ErrProbeStuck is reported when a probe is not run
+	// because it has not returned since exceeding its deadline
+	// or having its check interrupted.
+	ErrProbeStuck = errors.New("healthcheck probe is stuck")
+)
+
+// wrappedError is an error with its own message wrapping a sentinel error,
+// so that the message stays unchanged while errors.Is still matches.
+type wrappedError struct {
+	message string
+	err     error
+}
+
+// Error returns the message of the error.
+func (e *wrappedError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the wrapped sentinel error.
+func (e *wrappedError) Unwrap() error {
+	return e.err
+}
+
+// invalidOption returns an error with the given message wrapping ErrInvalidOption.
+func invalidOption(message string) error {
+	return &wrappedError{message: message, err: ErrInvalidOption}
+}
+
+// alreadyRegistered returns an error wrapping ErrProbeAlreadyRegistered
+// for the probe with the given name.
+func alreadyRegistered(name string) error {
+	return &wrappedError{
+		message: fmt.Sprintf("healthcheck probe '%s' already registered", name),
+		err:     ErrProbeAlreadyRegistered,
+	}
+}
+
+// optionErrors collects the errors of failed options.
+// Unless collecting, a failure panics with the error message instead.
+type optionErrors struct {
+	collecting bool
+	errs       []error
+}
+
+// fail records the error or panics with its message if not collecting.
+func (f *optionErrors) fail(err error) {
+	if !f.collecting {
+		panic(err.Error())
+	}
+
+	f.errs = append(f.errs, err)
+}
+
+// err returns the collected errors joined into one, or nil if there are none.
+func (f *optionErrors) err() error {
+	return errors.Join(f.errs...)
+}
+
+// StatusError is an error a probe can return to explicitly report
+// a specific status along with the reason for it.
+type StatusError struct {
+	Status Status
+	Reason string
+}
+
+// NewStatusError creates a new StatusError with the given status and reason.
+func NewStatusError(status Status, reason string) *StatusError {
+	return &StatusError{
+		Status: status,
+		Reason: reason,
+	}
+}
+
+// Degraded creates a new StatusError reporting StatusDegraded with the given reason.
+func Degraded(reason string) *StatusError {
+	return NewStatusError(StatusDegraded, reason)
+}
+
+// Error returns the status and the reason of the error.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Status, e.Reason)
+}
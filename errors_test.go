@@ -0,0 +1,76 @@
+package healthcheck
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusError_Error(t *testing.T) {
+	t.Parallel()
+
+	err := NewStatusError(StatusUnhealthy, "replica is down")
+	assert.EqualError(t, err, "unhealthy: replica is down")
+
+	err = Degraded("lag 40s")
+	assert.Equal(t, StatusDegraded, err.Status)
+	assert.Equal(t, "lag 40s", err.Reason)
+	assert.EqualError(t, err, "degraded: lag 40s")
+}
+
+func TestErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		err  error
+		want Status
+	}{
+		"nil": {
+			err:  nil,
+			want: StatusHealthy,
+		},
+		"plain_error": {
+			err:  errors.New("error"),
+			want: StatusUnhealthy,
+		},
+		"healthy": {
+			err:  NewStatusError(StatusHealthy, "fine"),
+			want: StatusHealthy,
+		},
+		"degraded": {
+			err:  Degraded("lag"),
+			want: StatusDegraded,
+		},
+		"degraded_wrapped": {
+			err:  fmt.Errorf("replica: %w", Degraded("lag")),
+			want: StatusDegraded,
+		},
+		"unhealthy": {
+			err:  NewStatusError(StatusUnhealthy, "down"),
+			want: StatusUnhealthy,
+		},
+		"starting": {
+			err:  NewStatusError(StatusStarting, "starting"),
+			want: StatusStarting,
+		},
+		"unknown": {
+			err:  NewStatusError(StatusUnknown, "unknown"),
+			want: StatusUnhealthy,
+		},
+		"invalid": {
+			err:  NewStatusError(Status(7), "invalid"),
+			want: StatusUnhealthy,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+				assert.Equal(t, tt.want, errorStatus(tt.err))
+			},
+		)
+	}
+}
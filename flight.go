@@ -0,0 +1,89 @@
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flight coalesces concurrent evaluations sharing the same key
+// and caches their results for a given TTL.
+type flight struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+type call struct {
+	done      chan struct{}
+	report    Report
+	expiresAt time.Time
+}
+
+// do returns the result of fn for the given key.
+// If an evaluation for the key is already in flight,
+// do waits for it instead of starting a new one.
+// If the last completed evaluation is younger than ttl, its result is reused.
+// fn is not cancelled when ctx is done, yet do returns a report
+// with StatusUnknown as soon as ctx is done.
+func (f *flight) do(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+	fn func(ctx context.Context) Report,
+) Report {
+	f.mu.Lock()
+
+	if f.calls == nil {
+		f.calls = map[string]*call{}
+	}
+
+	c, ok := f.calls[key]
+	if !ok || c.expired() {
+		c = &call{
+			done: make(chan struct{}),
+		}
+		f.calls[key] = c
+
+		go func() {
+			c.report = fn(context.WithoutCancel(ctx))
+			c.expiresAt = time.Now().Add(ttl)
+			close(c.done)
+		}()
+	}
+
+	f.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.report.clone()
+	case <-ctx.Done():
+		return newReport()
+	}
+}
+
+// reset discards all cached results,
+// so the next call for any key starts a new evaluation.
+// Evaluations in flight are not affected.
+func (f *flight) reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for key, c := range f.calls {
+		select {
+		case <-c.done:
+			delete(f.calls, key)
+		default:
+		}
+	}
+}
+
+// expired reports whether the call has completed and its result is outdated.
+// Must be called with flight's mutex held.
+func (c *call) expired() bool {
+	select {
+	case <-c.done:
+		return !time.Now().Before(c.expiresAt)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,94 @@
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlight_Do(t *testing.T) {
+	t.Parallel()
+
+	calls := atomic.Int32{}
+	release := make(chan struct{})
+
+	fn := func(_ context.Context) Report {
+		calls.Add(1)
+		<-release
+		return Report{Status: StatusHealthy}
+	}
+
+	f := &flight{}
+
+	wg := &sync.WaitGroup{}
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report := f.do(context.Background(), "key", 0, fn)
+			assert.Equal(t, StatusHealthy, report.Status)
+		}()
+	}
+
+	assert.Eventually(
+		t, func() bool {
+			return calls.Load() == 1
+		}, time.Second, time.Millisecond,
+	)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+
+	f.do(context.Background(), "key", 0, fn)
+	assert.Equal(t, int32(2), calls.Load())
+
+	f.do(context.Background(), "other", 0, fn)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestFlight_Do_TTL(t *testing.T) {
+	t.Parallel()
+
+	calls := atomic.Int32{}
+	fn := func(_ context.Context) Report {
+		calls.Add(1)
+		return Report{Status: StatusHealthy}
+	}
+
+	f := &flight{}
+
+	f.do(context.Background(), "key", 50*time.Millisecond, fn)
+	f.do(context.Background(), "key", 50*time.Millisecond, fn)
+	assert.Equal(t, int32(1), calls.Load())
+
+	time.Sleep(60 * time.Millisecond)
+
+	f.do(context.Background(), "key", 50*time.Millisecond, fn)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestFlight_Do_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	fn := func(ctx context.Context) Report {
+		<-release
+		assert.NoError(t, ctx.Err())
+		return Report{Status: StatusHealthy}
+	}
+
+	f := &flight{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	report := f.do(ctx, "key", 0, fn)
+	assert.Equal(t, StatusUnknown, report.Status)
+}
@@ -2,7 +2,10 @@ package healthcheck
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 	"time"
 )
@@ -10,18 +13,74 @@ import (
 // Healthcheck represents an application health checker with configurable probes and timeouts.
 type Healthcheck struct {
 	logger           *slog.Logger
-	probes           map[string]Probe
+	probesMu         sync.RWMutex
+	probes           map[string]*registration
 	timeoutDegraded  time.Duration
 	timeoutUnhealthy time.Duration
+	interval         time.Duration
+	cacheTTL         time.Duration
+	historySize      int
+	aggregator       Aggregator
+	deadlineGrace    time.Duration
+	optionErrs       optionErrors
+
+	overridesMu sync.Mutex
+	overrides   map[string]Override
+
+	startupPeriod   time.Duration
+	startupDeadline time.Time
+
+	statusListeners      []StatusListener
+	probeStatusListeners []ProbeStatusListener
+	statusNotifiers      []*notifier
+	probeStatusNotifiers []*notifier
+	statusesMu           sync.Mutex
+	statuses             map[string]Status
+
+	flight flight
+
+	mu      sync.Mutex
+	running bool
+	runCtx  context.Context
+	cancel  context.CancelFunc
+	workers *sync.WaitGroup
 }
 
+// defaultDeadlineGrace is the time a probe is given to return
+// after its unhealthy timeout before it is abandoned.
+const defaultDeadlineGrace = time.Second
+
 // New creates a new Healthcheck instance with the provided options.
+// Panics if any option is invalid.
 func New(opts ...Option) *Healthcheck {
+	hc, _ := newHealthcheck(opts, false)
+	return hc
+}
+
+// NewE creates a new Healthcheck instance with the provided options.
+// Unlike New, it does not panic on invalid options
+// and returns the errors of all of them joined instead.
+// Each of the errors wraps ErrInvalidOption, ErrNilProbe
+// or ErrProbeAlreadyRegistered.
+func NewE(opts ...Option) (*Healthcheck, error) {
+	hc, err := newHealthcheck(opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return hc, nil
+}
+
+func newHealthcheck(opts []Option, collect bool) (*Healthcheck, error) {
 	hc := &Healthcheck{
 		logger:           slog.Default(),
-		probes:           map[string]Probe{},
+		probes:           map[string]*registration{},
 		timeoutDegraded:  1 * time.Second,
 		timeoutUnhealthy: 10 * time.Second,
+		interval:         10 * time.Second,
+		aggregator:       WorstWins(),
+		deadlineGrace:    defaultDeadlineGrace,
+		optionErrs:       optionErrors{collecting: collect},
 	}
 
 	for _, opt := range opts {
@@ -29,103 +88,468 @@ func New(opts ...Option) *Healthcheck {
 	}
 
 	if hc.timeoutDegraded >= hc.timeoutUnhealthy {
-		panic("healthcheck degradation timeout must be less than unhealthy timeout")
+		hc.optionErrs.fail(
+			invalidOption(
+				"healthcheck degradation timeout must be less than unhealthy timeout",
+			),
+		)
 	}
 
-	return hc
+	err := hc.optionErrs.err()
+	hc.optionErrs = optionErrors{}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range hc.probes {
+		r.history = newHistory(hc.historySize)
+	}
+
+	if hc.startupPeriod > 0 {
+		hc.startupDeadline = time.Now().Add(hc.startupPeriod)
+	}
+
+	hc.statusNotifiers = newNotifiers(len(hc.statusListeners), hc.logger)
+	hc.probeStatusNotifiers = newNotifiers(
+		len(hc.probeStatusListeners), hc.logger,
+	)
+
+	return hc, nil
+}
+
+// Register registers a new health check probe with the given name
+// and the provided probe options.
+// It is safe to call Register concurrently with Handle and its variants.
+// If background probing is running, the probe is started immediately.
+// Returns an error if probe is nil, any probe option is invalid
+// or a probe with the same name already exists.
+func (hc *Healthcheck) Register(
+	name string, probe Probe, opts ...ProbeOption,
+) error {
+	if probe == nil {
+		return ErrNilProbe
+	}
+
+	r, err := newRegistration(probe, opts)
+	if err != nil {
+		return err
+	}
+
+	r.history = newHistory(hc.historySize)
+
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	hc.probesMu.Lock()
+	defer hc.probesMu.Unlock()
+
+	if _, ok := hc.probes[name]; ok {
+		return alreadyRegistered(name)
+	}
+
+	hc.probes[name] = r
+	hc.flight.reset()
+
+	if hc.running {
+		hc.startProbe(name, r)
+	}
+
+	return nil
 }
 
+// Unregister removes the probe with the given name.
+// It is safe to call Unregister concurrently with Handle and its variants.
+// If background probing is running, the probe is stopped.
+// Unregistering a probe which does not exist has no effect.
+func (hc *Healthcheck) Unregister(name string) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	hc.probesMu.Lock()
+	defer hc.probesMu.Unlock()
+
+	r, ok := hc.probes[name]
+	if !ok {
+		return
+	}
+
+	delete(hc.probes, name)
+	hc.flight.reset()
+
+	if r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+	}
+}
+
+// Abandoned returns the names of the probes which exceeded their deadline
+// and have not returned since.
+// Such probes are not run again until they return
+// and are reported as unhealthy meanwhile.
+func (hc *Healthcheck) Abandoned() []string {
+	hc.probesMu.RLock()
+	defer hc.probesMu.RUnlock()
+
+	var names []string
+	for name, r := range hc.probes {
+		if r.isAbandoned() {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+// Handle runs all registered probes and returns the aggregated status.
+// Unless background probing is started, it returns within the longest
+// unhealthy timeout of the probes plus the deadline grace set by WithDeadlineGrace.
 func (hc *Healthcheck) Handle(ctx context.Context) Status {
-	probeCount := len(hc.probes)
-	if probeCount == 0 {
-		return StatusUnknown
+	return hc.HandleReport(ctx).Status
+}
+
+// HandleReport runs all registered probes and returns
+// the aggregated status along with the result of every probe.
+func (hc *Healthcheck) HandleReport(ctx context.Context) Report {
+	return hc.handle(ctx, "")
+}
+
+// HandleGroup runs the probes of the given group and returns the aggregated status.
+func (hc *Healthcheck) HandleGroup(ctx context.Context, group string) Status {
+	return hc.HandleGroupReport(ctx, group).Status
+}
+
+// HandleGroupReport runs the probes of the given group and returns
+// the aggregated status along with the result of every probe.
+func (hc *Healthcheck) HandleGroupReport(
+	ctx context.Context, group string,
+) Report {
+	return hc.handle(ctx, group)
+}
+
+// Check runs all registered probes and returns an error
+// unless the aggregated status is healthy,
+// which makes Healthcheck usable as a Probe of another Healthcheck.
+// A degraded status is reported as a StatusError with StatusDegraded,
+// so that it is propagated to the parent as degraded.
+// Any other status is reported as a StatusError with that status,
+// so that a starting status is propagated to the parent as is
+// and any other one, including unknown, as unhealthy.
+func (hc *Healthcheck) Check(ctx context.Context) error {
+	_, err := hc.CheckReport(ctx)
+	return err
+}
+
+// CheckReport runs all registered probes like Check
+// and additionally returns the report the result is based on.
+// When Healthcheck is registered as a probe of another Healthcheck,
+// the report is nested into the result of the probe.
+// The report is nil if ctx is done, since the probes have not been evaluated.
+func (hc *Healthcheck) CheckReport(ctx context.Context) (*Report, error) {
+	report := hc.HandleReport(ctx)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return &report, report.Err()
+}
+
+// handle evaluates the probes of the given group,
+// or all probes if group is empty,
+// applies the status override if any and notifies the status listeners.
+func (hc *Healthcheck) handle(ctx context.Context, group string) Report {
+	if ctx.Err() != nil {
+		return newReport()
 	}
 
+	report := hc.report(ctx, group)
 	if ctx.Err() != nil {
-		return StatusUnknown
+		return newReport()
+	}
+
+	report = hc.applyOverride(report)
+	hc.observe(report)
+
+	return report
+}
+
+// report evaluates the probes of the given group, or all probes if group is empty.
+// Concurrent evaluations of the same group are coalesced
+// and their results are reused for the configured cache TTL.
+// If background probing is running, the last known results are used instead.
+func (hc *Healthcheck) report(ctx context.Context, group string) Report {
+	probes := hc.selectProbes(group)
+
+	if len(probes) == 0 {
+		report := newReport()
+		report.Group = group
+		return report
 	}
 
+	if hc.isRunning() {
+		return hc.cachedReport(group, probes)
+	}
+
+	return hc.flight.do(
+		ctx, group, hc.cacheTTL, func(ctx context.Context) Report {
+			report := hc.evaluate(ctx, probes)
+			report.Group = group
+			return report
+		},
+	)
+}
+
+// cachedReport builds a report from the last known results of the probes.
+// While any critical probe has not completed yet,
+// the aggregated status is at least StatusStarting,
+// so that the application is not reported ready
+// before all of its dependencies have been checked.
+func (hc *Healthcheck) cachedReport(
+	group string, probes map[string]*registration,
+) Report {
+	report := newReport()
+	report.Group = group
+
+	pending := false
+	for name, r := range probes {
+		result := r.lastResult()
+		report.Probes[name] = result
+
+		if result.Status == StatusUnknown && result.Critical {
+			pending = true
+		}
+	}
+
+	report.Status = hc.aggregator.Aggregate(report.Probes)
+	if pending {
+		report.Status = worse(report.Status, StatusStarting)
+	}
+
+	return report
+}
+
+func (hc *Healthcheck) selectProbes(group string) map[string]*registration {
+	hc.probesMu.RLock()
+	defer hc.probesMu.RUnlock()
+
+	probes := map[string]*registration{}
+	for name, r := range hc.probes {
+		if group == "" || r.inGroup(group) {
+			probes[name] = r
+		}
+	}
+
+	return probes
+}
+
+func (hc *Healthcheck) evaluate(
+	ctx context.Context, probes map[string]*registration,
+) Report {
+	report := newReport()
+	probeCount := len(probes)
+
 	wg := &sync.WaitGroup{}
 	wg.Add(probeCount)
 
-	statuses := make(chan Status, probeCount)
+	results := make(chan namedResult, probeCount)
 
-	for name, probe := range hc.probes {
+	for name, r := range probes {
 		pl := hc.logger.With("probe", name)
-		go hc.probeCheck(pl, ctx, wg, statuses, probe)
+		go func() {
+			defer wg.Done()
+			results <- namedResult{
+				name:   name,
+				result: hc.check(pl, ctx, name, r),
+			}
+		}()
 	}
 
 	wg.Wait()
-	close(statuses)
+	close(results)
+
+	for r := range results {
+		report.Probes[r.name] = r.result
+	}
+
+	report.Status = hc.aggregator.Aggregate(report.Probes)
+	return report
+}
+
+type namedResult struct {
+	name   string
+	result ProbeResult
+}
+
+// check runs the probe and records its result.
+func (hc *Healthcheck) check(
+	logger *slog.Logger,
+	ctx context.Context,
+	name string,
+	r *registration,
+) ProbeResult {
+	return hc.record(name, r, hc.probeCheck(logger, ctx, r))
+}
 
-	return hc.calculateStatus(statuses)
+// record records the result of the probe
+// and notifies the listeners if the probe status has changed.
+func (hc *Healthcheck) record(
+	name string, r *registration, result ProbeResult,
+) ProbeResult {
+	result, previous := r.record(result, hc.startupDeadline)
+	if result.Status != previous {
+		hc.notifyProbe(name, previous, result)
+	}
+
+	return result
 }
 
 func (hc *Healthcheck) probeCheck(
 	logger *slog.Logger,
 	ctx context.Context,
-	wg *sync.WaitGroup,
-	statuses chan Status,
-	probe Probe,
-) {
-	defer wg.Done()
-
-	defer func() {
-		if err := recover(); err != nil {
-			logger.ErrorContext(
-				ctx,
-				"probe panicked",
-				"panic", err,
-			)
-
-			statuses <- StatusUnhealthy
-		}
-	}()
+	r *registration,
+) (result ProbeResult) {
+	result.StartedAt = time.Now()
+	result.Critical = r.critical
+	result.TimeoutDegraded, result.TimeoutUnhealthy = hc.probeTimeouts(r)
+
+	if r.isAbandoned() {
+		logger.ErrorContext(
+			ctx,
+			"probe is still running since deadline was exceeded",
+		)
 
-	timedCtx, cancel := context.WithTimeout(ctx, hc.timeoutUnhealthy)
+		result.Status = StatusUnhealthy
+		result.Error = ErrProbeStuck
+		result.Abandoned = true
+		return result
+	}
+
+	timedCtx, cancel := context.WithTimeout(ctx, result.TimeoutUnhealthy)
 	defer cancel()
 
-	probeTime := time.Now()
-	err := probe.Check(timedCtx)
-	probeDuration := time.Since(probeTime)
+	run := &probeRun{
+		outcome: make(chan probeOutcome, 1),
+	}
+	go r.check(timedCtx, run)
+
+	// The hard deadline leaves the probe a grace period to return
+	// after its context is done, so that only probes ignoring the context
+	// are abandoned, while the others report their own error.
+	deadline := time.NewTimer(result.TimeoutUnhealthy + hc.deadlineGrace)
+	defer deadline.Stop()
+
+	var outcome probeOutcome
+	select {
+	case outcome = <-run.outcome:
+	case <-ctx.Done():
+		// The check has been interrupted, such as by Stop,
+		// so the probe is not waited for until its deadline.
+		// It is tracked as abandoned until it returns.
+		r.abandon(run)
+
+		result.Duration = time.Since(result.StartedAt)
+		result.Status = StatusUnhealthy
+		result.Error = ctx.Err()
+		return result
+	case <-deadline.C:
+		r.abandon(run)
+
+		result.Duration = time.Since(result.StartedAt)
+
+		logger.ErrorContext(
+			ctx,
+			"probe exceeded deadline",
+			"duration", result.Duration.String(),
+		)
+
+		result.Status = StatusUnhealthy
+		result.Error = ErrProbeDeadlineExceeded
+		result.Abandoned = true
+		return result
+	}
+
+	result.Duration = time.Since(result.StartedAt)
+
+	if outcome.panicked {
+		logger.ErrorContext(
+			ctx,
+			"probe panicked",
+			"panic", outcome.panic,
+		)
+
+		result.Status = StatusUnhealthy
+		result.Error = fmt.Errorf("probe panicked: %v", outcome.panic)
+		return result
+	}
+
+	err := outcome.err
+	result.Error = err
+	result.Details = outcome.details
+	result.Report = outcome.report
 
-	if err != nil || probeDuration > hc.timeoutUnhealthy {
+	reported := errorStatus(err)
+
+	if reported == StatusUnhealthy || result.Duration > result.TimeoutUnhealthy {
 		logger.ErrorContext(
 			ctx,
 			"failed to probe",
 			"error", err,
-			"duration", probeDuration.String(),
+			"duration", result.Duration.String(),
 		)
 
-		statuses <- StatusUnhealthy
-		return
+		result.Status = StatusUnhealthy
+		return result
 	}
 
-	if probeDuration > hc.timeoutDegraded {
+	if reported == StatusStarting {
+		result.Status = reported
+		return result
+	}
+
+	if reported == StatusDegraded || result.Duration > result.TimeoutDegraded {
 		logger.WarnContext(
 			ctx,
 			"probe is degraded",
-			"duration", probeDuration.String(),
+			"error", err,
+			"duration", result.Duration.String(),
 		)
 
-		statuses <- StatusDegraded
-		return
+		result.Status = StatusDegraded
+		return result
 	}
 
-	statuses <- StatusHealthy
+	result.Status = StatusHealthy
+	return result
 }
 
-func (hc *Healthcheck) calculateStatus(statuses chan Status) Status {
-	status := StatusHealthy
-	for s := range statuses {
-		if s <= status {
-			continue
-		}
+// errorStatus returns the status reported by the error returned from a probe.
+// Errors other than StatusError are considered unhealthy.
+func errorStatus(err error) Status {
+	if err == nil {
+		return StatusHealthy
+	}
 
-		status = s
-		if status == StatusUnhealthy {
-			break
-		}
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return StatusUnhealthy
+	}
+
+	switch statusErr.Status {
+	case StatusHealthy, StatusDegraded, StatusStarting:
+		return statusErr.Status
+	default:
+		return StatusUnhealthy
 	}
-	return status
+}
+
+// probeTimeouts returns the degradation and unhealthy timeouts of the probe,
+// falling back to the global ones if the probe does not override them.
+func (hc *Healthcheck) probeTimeouts(
+	r *registration,
+) (time.Duration, time.Duration) {
+	if r.timeoutUnhealthy == 0 {
+		return hc.timeoutDegraded, hc.timeoutUnhealthy
+	}
+
+	return r.timeoutDegraded, r.timeoutUnhealthy
 }
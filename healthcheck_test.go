@@ -3,8 +3,10 @@ package healthcheck
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"sync"
 	"testing"
 	"time"
 
@@ -46,6 +48,85 @@ func TestNew(t *testing.T) {
 	)
 }
 
+func TestNewE(t *testing.T) {
+	t.Parallel()
+
+	probe := healthcheck.NewMockProbe(t)
+
+	tests := map[string]struct {
+		opts []Option
+		errs []error
+		msgs []string
+	}{
+		"valid": {
+			opts: []Option{
+				WithProbe("probe", probe),
+				WithTimeoutDegraded(time.Second),
+			},
+		},
+		"invalid option": {
+			opts: []Option{WithLogger(nil)},
+			errs: []error{ErrInvalidOption},
+			msgs: []string{"healthcheck logger cannot be nil"},
+		},
+		"invalid probe option": {
+			opts: []Option{WithProbe("probe", probe, ProbeGroups())},
+			errs: []error{ErrInvalidOption},
+			msgs: []string{"healthcheck probe groups cannot be empty"},
+		},
+		"timeouts": {
+			opts: []Option{
+				WithTimeoutDegraded(5 * time.Second),
+				WithTimeoutUnhealthy(5 * time.Second),
+			},
+			errs: []error{ErrInvalidOption},
+			msgs: []string{
+				"healthcheck degradation timeout must be less than unhealthy timeout",
+			},
+		},
+		"multiple": {
+			opts: []Option{
+				WithProbe("probe", nil),
+				WithProbe("probe", probe),
+				WithProbe("probe", probe),
+				WithInterval(0),
+			},
+			errs: []error{
+				ErrNilProbe, ErrProbeAlreadyRegistered, ErrInvalidOption,
+			},
+			msgs: []string{
+				"healthcheck probe cannot be nil",
+				"healthcheck probe 'probe' already registered",
+				"healthcheck interval must be greater than zero",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				hc, err := NewE(tt.opts...)
+
+				if len(tt.errs) == 0 {
+					assert.NoError(t, err)
+					assert.NotNil(t, hc)
+					return
+				}
+
+				assert.Nil(t, hc)
+				for _, target := range tt.errs {
+					assert.ErrorIs(t, err, target)
+				}
+				for _, msg := range tt.msgs {
+					assert.ErrorContains(t, err, msg)
+				}
+			},
+		)
+	}
+}
+
 func TestHealthcheck_Handle(t *testing.T) {
 	t.Parallel()
 
@@ -128,6 +209,28 @@ func TestHealthcheck_Handle(t *testing.T) {
 				return New(WithProbe("probe", probe))
 			},
 		},
+		"one_probe_reported_degraded": {
+			status: StatusDegraded,
+			setup: func(t *testing.T) *Healthcheck {
+				probe := healthcheck.NewMockProbe(t)
+				probe.EXPECT().Check(mock.Anything).Return(
+					Degraded("replica lag 40s"),
+				)
+
+				return New(WithProbe("probe", probe))
+			},
+		},
+		"one_probe_reported_unhealthy": {
+			status: StatusUnhealthy,
+			setup: func(t *testing.T) *Healthcheck {
+				probe := healthcheck.NewMockProbe(t)
+				probe.EXPECT().Check(mock.Anything).Return(
+					NewStatusError(StatusUnhealthy, "replica is down"),
+				)
+
+				return New(WithProbe("probe", probe))
+			},
+		},
 		"one_probe_panic": {
 			status: StatusUnhealthy,
 			setup: func(t *testing.T) *Healthcheck {
@@ -137,6 +240,46 @@ func TestHealthcheck_Handle(t *testing.T) {
 				return New(WithProbe("probe", probe))
 			},
 		},
+		"one_probe_override_timeout_degraded": {
+			status: StatusDegraded,
+			setup: func(t *testing.T) *Healthcheck {
+				probe := healthcheck.NewMockProbe(t)
+				probe.EXPECT().Check(mock.Anything).RunAndReturn(
+					func(_ context.Context) error {
+						time.Sleep(20 * time.Millisecond)
+						return nil
+					},
+				)
+
+				return New(
+					WithProbe(
+						"probe", probe,
+						ProbeTimeouts(10*time.Millisecond, time.Second),
+					),
+				)
+			},
+		},
+		"one_probe_override_timeout_healthy": {
+			status: StatusHealthy,
+			setup: func(t *testing.T) *Healthcheck {
+				probe := healthcheck.NewMockProbe(t)
+				probe.EXPECT().Check(mock.Anything).RunAndReturn(
+					func(_ context.Context) error {
+						time.Sleep(20 * time.Millisecond)
+						return nil
+					},
+				)
+
+				return New(
+					WithTimeoutDegraded(10*time.Millisecond),
+					WithTimeoutUnhealthy(15*time.Millisecond),
+					WithProbe(
+						"probe", probe,
+						ProbeTimeouts(time.Second, 2*time.Second),
+					),
+				)
+			},
+		},
 		"multiple_probes_healthy": {
 			status: StatusHealthy,
 			setup: func(t *testing.T) *Healthcheck {
@@ -208,6 +351,40 @@ func TestHealthcheck_Handle(t *testing.T) {
 				)
 			},
 		},
+		"non_critical_probe_error": {
+			status: StatusDegraded,
+			setup: func(t *testing.T) *Healthcheck {
+				p1 := healthcheck.NewMockProbe(t)
+				p2 := healthcheck.NewMockProbe(t)
+				p1.EXPECT().Check(mock.Anything).Return(
+					errors.New("p1 error"),
+				)
+				p2.EXPECT().Check(mock.Anything).Return(nil)
+
+				return New(
+					WithProbe("p1", p1, ProbeNonCritical()),
+					WithProbe("p2", p2),
+				)
+			},
+		},
+		"non_critical_and_critical_probes_error": {
+			status: StatusUnhealthy,
+			setup: func(t *testing.T) *Healthcheck {
+				p1 := healthcheck.NewMockProbe(t)
+				p2 := healthcheck.NewMockProbe(t)
+				p1.EXPECT().Check(mock.Anything).Return(
+					errors.New("p1 error"),
+				)
+				p2.EXPECT().Check(mock.Anything).Return(
+					errors.New("p2 error"),
+				)
+
+				return New(
+					WithProbe("p1", p1, ProbeNonCritical()),
+					WithProbe("p2", p2),
+				)
+			},
+		},
 	}
 
 	for name, tt := range tests {
@@ -230,3 +407,508 @@ func TestHealthcheck_Handle(t *testing.T) {
 		)
 	}
 }
+
+func TestHealthcheck_HandleReport(t *testing.T) {
+	t.Parallel()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	probeErr := errors.New("probe error")
+
+	healthy := healthcheck.NewMockProbe(t)
+	healthy.EXPECT().Check(mock.Anything).Return(nil)
+
+	failing := healthcheck.NewMockProbe(t)
+	failing.EXPECT().Check(mock.Anything).Return(probeErr)
+
+	panicking := healthcheck.NewMockProbe(t)
+	panicking.EXPECT().Check(mock.Anything).Panic("probe panic")
+
+	hc := New(
+		WithProbe("healthy", healthy, ProbeTimeouts(30*time.Second, time.Minute)),
+		WithProbe("failing", failing, ProbeNonCritical()),
+		WithProbe("panicking", panicking),
+	)
+
+	before := time.Now()
+	report := hc.HandleReport(context.Background())
+
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Len(t, report.Probes, 3)
+
+	assert.Equal(t, StatusHealthy, report.Probes["healthy"].Status)
+	assert.NoError(t, report.Probes["healthy"].Error)
+	assert.Equal(t, 30*time.Second, report.Probes["healthy"].TimeoutDegraded)
+	assert.Equal(t, time.Minute, report.Probes["healthy"].TimeoutUnhealthy)
+
+	assert.Equal(t, StatusUnhealthy, report.Probes["failing"].Status)
+	assert.ErrorIs(t, report.Probes["failing"].Error, probeErr)
+	assert.False(t, report.Probes["failing"].Critical)
+
+	assert.Equal(t, StatusUnhealthy, report.Probes["panicking"].Status)
+	assert.EqualError(
+		t, report.Probes["panicking"].Error, "probe panicked: probe panic",
+	)
+
+	assert.Equal(t, time.Second, report.Probes["failing"].TimeoutDegraded)
+	assert.Equal(t, 10*time.Second, report.Probes["failing"].TimeoutUnhealthy)
+
+	for _, r := range report.Probes {
+		assert.False(t, r.StartedAt.Before(before))
+		assert.GreaterOrEqual(t, r.Duration, time.Duration(0))
+	}
+
+	empty := New().HandleReport(context.Background())
+	assert.Equal(t, StatusUnknown, empty.Status)
+	assert.Empty(t, empty.Probes)
+}
+
+func TestHealthcheck_HandleGroup(t *testing.T) {
+	t.Parallel()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	liveness := healthcheck.NewMockProbe(t)
+	liveness.EXPECT().Check(mock.Anything).Return(nil)
+
+	readiness := healthcheck.NewMockProbe(t)
+	readiness.EXPECT().Check(mock.Anything).Return(errors.New("not ready"))
+
+	common := healthcheck.NewMockProbe(t)
+	common.EXPECT().Check(mock.Anything).Return(nil)
+
+	hc := New(
+		WithProbe("liveness", liveness, ProbeGroups(GroupLiveness)),
+		WithProbe("readiness", readiness, ProbeGroups(GroupReadiness)),
+		WithProbe("common", common),
+	)
+
+	ctx := context.Background()
+
+	report := hc.HandleGroupReport(ctx, GroupLiveness)
+	assert.Equal(t, StatusHealthy, report.Status)
+	assert.Len(t, report.Probes, 2)
+	assert.Contains(t, report.Probes, "liveness")
+	assert.Contains(t, report.Probes, "common")
+
+	report = hc.HandleGroupReport(ctx, GroupReadiness)
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Len(t, report.Probes, 2)
+	assert.Contains(t, report.Probes, "readiness")
+	assert.Contains(t, report.Probes, "common")
+
+	assert.Equal(t, StatusHealthy, hc.HandleGroup(ctx, GroupStartup))
+	assert.Equal(t, StatusUnhealthy, hc.Handle(ctx))
+
+	empty := New(
+		WithProbe("liveness", liveness, ProbeGroups(GroupLiveness)),
+	)
+	assert.Equal(t, StatusUnknown, empty.HandleGroup(ctx, GroupStartup))
+}
+
+func TestHealthcheck_Handle_CacheTTL(t *testing.T) {
+	t.Parallel()
+
+	probe := healthcheck.NewMockProbe(t)
+	probe.EXPECT().Check(mock.Anything).Return(nil).Once()
+
+	hc := New(
+		WithCacheTTL(time.Minute),
+		WithProbe("probe", probe),
+	)
+
+	ctx := context.Background()
+	assert.Equal(t, StatusHealthy, hc.Handle(ctx))
+	assert.Equal(t, StatusHealthy, hc.Handle(ctx))
+}
+
+func TestHealthcheck_Register(t *testing.T) {
+	t.Parallel()
+
+	hc := New()
+
+	assert.ErrorIs(t, hc.Register("probe", nil), ErrNilProbe)
+
+	probe := healthcheck.NewMockProbe(t)
+	probe.EXPECT().Check(mock.Anything).Return(nil)
+
+	assert.NoError(t, hc.Register("probe", probe, ProbeNonCritical()))
+	assert.Equal(t, probe, hc.probes["probe"].probe)
+	assert.False(t, hc.probes["probe"].critical)
+
+	err := hc.Register("other", probe, ProbeThresholds(0, 1))
+	assert.ErrorIs(t, err, ErrInvalidOption)
+	assert.NotContains(t, hc.probes, "other")
+
+	err = hc.Register("probe", probe)
+	assert.ErrorIs(t, err, ErrProbeAlreadyRegistered)
+	assert.EqualError(t, err, "healthcheck probe 'probe' already registered")
+
+	assert.Equal(t, StatusHealthy, hc.Handle(context.Background()))
+}
+
+func TestHealthcheck_Unregister(t *testing.T) {
+	t.Parallel()
+
+	probe := healthcheck.NewMockProbe(t)
+	probe.EXPECT().Check(mock.Anything).Return(nil).Once()
+
+	hc := New(
+		WithCacheTTL(time.Minute),
+		WithProbe("probe", probe),
+	)
+
+	ctx := context.Background()
+	assert.Equal(t, StatusHealthy, hc.Handle(ctx))
+
+	hc.Unregister("probe")
+	hc.Unregister("unknown")
+
+	assert.Empty(t, hc.probes)
+	assert.Equal(t, StatusUnknown, hc.Handle(ctx))
+}
+
+func TestHealthcheck_Register_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	hc := New()
+	ctx := context.Background()
+
+	wg := &sync.WaitGroup{}
+	for i := range 10 {
+		name := fmt.Sprintf("probe-%d", i)
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+
+			err := hc.Register(
+				name, &probe{
+					check: func(_ context.Context) error {
+						return nil
+					},
+				},
+			)
+			assert.NoError(t, err)
+
+			hc.Unregister(name)
+		}()
+		go func() {
+			defer wg.Done()
+			hc.Handle(ctx)
+		}()
+	}
+
+	wg.Wait()
+	assert.Empty(t, hc.probes)
+}
+
+func TestHealthcheck_Handle_Abandoned(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	release := make(chan struct{})
+
+	probe := healthcheck.NewMockProbe(t)
+	probe.EXPECT().Check(mock.Anything).RunAndReturn(
+		func(_ context.Context) error {
+			calls++
+			<-release
+			return nil
+		},
+	).Once()
+
+	hc := New(
+		WithTimeoutDegraded(10*time.Millisecond),
+		WithTimeoutUnhealthy(20*time.Millisecond),
+		WithDeadlineGrace(10*time.Millisecond),
+		WithProbe("probe", probe),
+	)
+
+	ctx := context.Background()
+
+	report := hc.HandleReport(ctx)
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.ErrorIs(t, report.Probes["probe"].Error, ErrProbeDeadlineExceeded)
+	assert.True(t, report.Probes["probe"].Abandoned)
+	assert.Equal(t, []string{"probe"}, hc.Abandoned())
+
+	report = hc.HandleReport(ctx)
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.ErrorIs(t, report.Probes["probe"].Error, ErrProbeStuck)
+	assert.True(t, report.Probes["probe"].Abandoned)
+
+	close(release)
+
+	assert.Eventually(
+		t, func() bool {
+			return len(hc.Abandoned()) == 0
+		}, time.Second, time.Millisecond,
+	)
+	assert.Equal(t, 1, calls)
+
+	probe.EXPECT().Check(mock.Anything).Return(nil).Once()
+	assert.Equal(t, StatusHealthy, hc.Handle(ctx))
+}
+
+func TestHealthcheck_Handle_DeadlineGrace(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	hc := New(
+		WithTimeoutDegraded(10*time.Millisecond),
+		WithTimeoutUnhealthy(50*time.Millisecond),
+		WithDeadlineGrace(0),
+		WithSimpleProbe(
+			"probe", func(_ context.Context) error {
+				<-release
+				return nil
+			},
+		),
+	)
+
+	startedAt := time.Now()
+	report := hc.HandleReport(context.Background())
+
+	assert.Less(t, time.Since(startedAt), 500*time.Millisecond)
+	assert.ErrorIs(t, report.Probes["probe"].Error, ErrProbeDeadlineExceeded)
+	assert.True(t, report.Probes["probe"].Abandoned)
+}
+
+func TestHealthcheck_Handle_ContextDeadline(t *testing.T) {
+	t.Parallel()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	hc := New(
+		WithTimeoutDegraded(5*time.Millisecond),
+		WithTimeoutUnhealthy(10*time.Millisecond),
+		WithSimpleProbe(
+			"probe", func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		),
+	)
+
+	ctx := context.Background()
+
+	for range 20 {
+		report := hc.HandleReport(ctx)
+		assert.Equal(t, StatusUnhealthy, report.Status)
+		assert.ErrorIs(
+			t, report.Probes["probe"].Error, context.DeadlineExceeded,
+		)
+		assert.False(t, report.Probes["probe"].Abandoned)
+	}
+
+	assert.Empty(t, hc.Abandoned())
+}
+
+func TestHealthcheck_Handle_Thresholds(t *testing.T) {
+	t.Parallel()
+
+	probeErr := errors.New("probe error")
+
+	steps := []struct {
+		err       error
+		status    Status
+		failures  int
+		successes int
+	}{
+		{err: nil, status: StatusHealthy, failures: 0, successes: 1},
+		{err: probeErr, status: StatusDegraded, failures: 1, successes: 0},
+		{err: nil, status: StatusHealthy, failures: 0, successes: 1},
+		{err: probeErr, status: StatusDegraded, failures: 1, successes: 0},
+		{err: probeErr, status: StatusUnhealthy, failures: 2, successes: 0},
+		{err: probeErr, status: StatusUnhealthy, failures: 3, successes: 0},
+		{err: nil, status: StatusUnhealthy, failures: 0, successes: 1},
+		{err: probeErr, status: StatusUnhealthy, failures: 1, successes: 0},
+		{err: nil, status: StatusUnhealthy, failures: 0, successes: 1},
+		{err: nil, status: StatusHealthy, failures: 0, successes: 2},
+	}
+
+	probe := healthcheck.NewMockProbe(t)
+	for _, step := range steps {
+		probe.EXPECT().Check(mock.Anything).Return(step.err).Once()
+	}
+
+	hc := New(
+		WithProbe("probe", probe, ProbeThresholds(2, 2)),
+	)
+
+	for i, step := range steps {
+		result := hc.HandleReport(context.Background()).Probes["probe"]
+		assert.Equal(t, step.status, result.Status, "step %d", i)
+		assert.Equal(t, step.failures, result.ConsecutiveFailures, "step %d", i)
+		assert.Equal(t, step.successes, result.ConsecutiveSuccesses, "step %d", i)
+	}
+}
+
+func TestHealthcheck_Handle_Aggregator(t *testing.T) {
+	t.Parallel()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	replica := func(err error) *healthcheck.MockProbe {
+		probe := healthcheck.NewMockProbe(t)
+		probe.EXPECT().Check(mock.Anything).Return(err)
+		return probe
+	}
+
+	hc := New(
+		WithAggregator(Quorum(2)),
+		WithProbe("redis-1", replica(nil)),
+		WithProbe("redis-2", replica(nil)),
+		WithProbe("redis-3", replica(errors.New("connection refused"))),
+	)
+
+	assert.Equal(t, StatusHealthy, hc.Handle(context.Background()))
+}
+
+func TestHealthcheck_Handle_StartupGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	probeErr := errors.New("probe error")
+
+	warming := healthcheck.NewMockProbe(t)
+	warming.EXPECT().Check(mock.Anything).Return(probeErr).Once()
+	warming.EXPECT().Check(mock.Anything).Return(nil).Once()
+	warming.EXPECT().Check(mock.Anything).Return(probeErr).Once()
+
+	hc := New(
+		WithStartupGracePeriod(time.Minute),
+		WithProbe("warming", warming),
+	)
+
+	ctx := context.Background()
+	assert.Equal(t, StatusStarting, hc.Handle(ctx))
+	assert.Equal(t, StatusHealthy, hc.Handle(ctx))
+	assert.Equal(t, StatusUnhealthy, hc.Handle(ctx))
+
+	expired := healthcheck.NewMockProbe(t)
+	expired.EXPECT().Check(mock.Anything).Return(probeErr).Once()
+
+	hc = New(
+		WithStartupGracePeriod(time.Millisecond),
+		WithProbe("expired", expired),
+	)
+
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, StatusUnhealthy, hc.Handle(ctx))
+}
+
+func TestHealthcheck_HandleReport_Details(t *testing.T) {
+	t.Parallel()
+
+	details := map[string]any{"lag": "40s"}
+
+	probe := healthcheck.NewMockDetailedProbe(t)
+	probe.EXPECT().CheckDetails(mock.Anything).Return(
+		details, Degraded("replica lag"),
+	)
+
+	hc := New(WithProbe("replica", probe))
+
+	report := hc.HandleReport(context.Background())
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, details, report.Probes["replica"].Details)
+}
+
+func TestHealthcheck_Check(t *testing.T) {
+	t.Parallel()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tests := map[string]struct {
+		opts   []Option
+		err    error
+		child  Status
+		parent Status
+	}{
+		"healthy": {
+			child:  StatusHealthy,
+			parent: StatusHealthy,
+		},
+		"starting": {
+			opts:   []Option{WithStartupGracePeriod(time.Minute)},
+			err:    errors.New("connection refused"),
+			child:  StatusStarting,
+			parent: StatusStarting,
+		},
+		"degraded": {
+			err:    Degraded("replica lag"),
+			child:  StatusDegraded,
+			parent: StatusDegraded,
+		},
+		"unhealthy": {
+			err:    errors.New("connection refused"),
+			child:  StatusUnhealthy,
+			parent: StatusUnhealthy,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				probe := healthcheck.NewMockProbe(t)
+				probe.EXPECT().Check(mock.Anything).Return(tt.err)
+
+				child := New(append(tt.opts, WithProbe("database", probe))...)
+				parent := New(WithProbe("payments", child))
+
+				report := parent.HandleReport(context.Background())
+				assert.Equal(t, tt.parent, report.Status)
+
+				nested := report.Probes["payments"].Report
+				if assert.NotNil(t, nested) {
+					assert.Equal(t, tt.child, nested.Status)
+					assert.Equal(
+						t, tt.child, nested.Probes["database"].Status,
+					)
+				}
+
+				var statusErr *StatusError
+				err := child.Check(context.Background())
+				if tt.child == StatusHealthy {
+					assert.NoError(t, err)
+				} else if assert.ErrorAs(t, err, &statusErr) {
+					assert.Equal(t, tt.child, statusErr.Status)
+				}
+			},
+		)
+	}
+}
+
+func TestHealthcheck_Check_Unknown(t *testing.T) {
+	t.Parallel()
+
+	parent := New(
+		WithProbe("payments", New()),
+		WithSimpleProbe(
+			"database", func(_ context.Context) error {
+				return nil
+			},
+		),
+	)
+
+	report := parent.HandleReport(context.Background())
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Equal(t, StatusUnhealthy, report.Probes["payments"].Status)
+	assert.Equal(t, StatusUnknown, report.Probes["payments"].Report.Status)
+}
+
+func TestHealthcheck_CheckReport_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := New().CheckReport(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, report)
+}
@@ -0,0 +1,88 @@
+package healthcheck
+
+// History returns the retained results of the probe with the given name,
+// oldest first.
+// Returns nil if the probe does not exist or history is not enabled.
+func (hc *Healthcheck) History(name string) []ProbeResult {
+	hc.probesMu.RLock()
+	r, ok := hc.probes[name]
+	hc.probesMu.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	return r.historyResults()
+}
+
+// Histories returns the retained results of every probe by its name,
+// oldest first.
+// Returns an empty map if history is not enabled.
+func (hc *Healthcheck) Histories() map[string][]ProbeResult {
+	hc.probesMu.RLock()
+	defer hc.probesMu.RUnlock()
+
+	histories := map[string][]ProbeResult{}
+	for name, r := range hc.probes {
+		if results := r.historyResults(); results != nil {
+			histories[name] = results
+		}
+	}
+
+	return histories
+}
+
+func (r *registration) historyResults() []ProbeResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.history.results()
+}
+
+// history is a fixed size ring buffer of probe results.
+// A nil history retains nothing.
+type history struct {
+	buf  []ProbeResult
+	next int
+	full bool
+}
+
+func newHistory(size int) *history {
+	if size <= 0 {
+		return nil
+	}
+
+	return &history{
+		buf: make([]ProbeResult, size),
+	}
+}
+
+func (h *history) add(result ProbeResult) {
+	if h == nil {
+		return
+	}
+
+	h.buf[h.next] = result
+	h.next = (h.next + 1) % len(h.buf)
+
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// results returns a copy of the retained results, oldest first.
+func (h *history) results() []ProbeResult {
+	if h == nil {
+		return nil
+	}
+
+	if !h.full {
+		return append([]ProbeResult{}, h.buf[:h.next]...)
+	}
+
+	results := make([]ProbeResult, 0, len(h.buf))
+	results = append(results, h.buf[h.next:]...)
+	results = append(results, h.buf[:h.next]...)
+
+	return results
+}
@@ -0,0 +1,79 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nijeti/healthcheck/internal/generated/mocks"
+)
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	var h *history
+	h.add(ProbeResult{})
+	assert.Nil(t, h.results())
+	assert.Nil(t, newHistory(0))
+
+	h = newHistory(3)
+	assert.Empty(t, h.results())
+
+	for i := range 5 {
+		h.add(ProbeResult{ConsecutiveSuccesses: i})
+
+		results := h.results()
+		assert.Len(t, results, min(i+1, 3))
+		assert.Equal(t, i, results[len(results)-1].ConsecutiveSuccesses)
+	}
+
+	results := h.results()
+	assert.Equal(t, 2, results[0].ConsecutiveSuccesses)
+	assert.Equal(t, 3, results[1].ConsecutiveSuccesses)
+	assert.Equal(t, 4, results[2].ConsecutiveSuccesses)
+}
+
+func TestHealthcheck_History(t *testing.T) {
+	t.Parallel()
+
+	probeErr := errors.New("probe error")
+
+	p1 := healthcheck.NewMockProbe(t)
+	p1.EXPECT().Check(mock.Anything).Return(nil).Once()
+	p1.EXPECT().Check(mock.Anything).Return(probeErr).Once()
+	p1.EXPECT().Check(mock.Anything).Return(nil).Once()
+
+	p2 := healthcheck.NewMockProbe(t)
+	p2.EXPECT().Check(mock.Anything).Return(nil).Once()
+
+	hc := New(
+		WithHistorySize(2),
+		WithProbe("p1", p1),
+	)
+	assert.NoError(t, hc.Register("p2", p2, ProbeGroups(GroupStartup)))
+
+	ctx := context.Background()
+	hc.Handle(ctx)
+	hc.HandleGroup(ctx, GroupLiveness)
+	hc.HandleGroup(ctx, GroupLiveness)
+
+	history := hc.History("p1")
+	assert.Len(t, history, 2)
+	assert.Equal(t, StatusUnhealthy, history[0].Status)
+	assert.ErrorIs(t, history[0].Error, probeErr)
+	assert.Equal(t, StatusHealthy, history[1].Status)
+
+	assert.Nil(t, hc.History("unknown"))
+
+	histories := hc.Histories()
+	assert.Len(t, histories, 2)
+	assert.Equal(t, history, histories["p1"])
+	assert.Len(t, histories["p2"], 1)
+
+	disabled := New(WithProbe("p1", p1))
+	assert.Nil(t, disabled.History("p1"))
+	assert.Empty(t, disabled.Histories())
+}
@@ -0,0 +1,140 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package healthcheck
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDetailedProbe is an autogenerated mock type for the DetailedProbe type
+type MockDetailedProbe struct {
+	mock.Mock
+}
+
+type MockDetailedProbe_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDetailedProbe) EXPECT() *MockDetailedProbe_Expecter {
+	return &MockDetailedProbe_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with given fields: ctx
+func (_m *MockDetailedProbe) Check(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDetailedProbe_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type MockDetailedProbe_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDetailedProbe_Expecter) Check(ctx interface{}) *MockDetailedProbe_Check_Call {
+	return &MockDetailedProbe_Check_Call{Call: _e.mock.On("Check", ctx)}
+}
+
+func (_c *MockDetailedProbe_Check_Call) Run(run func(ctx context.Context)) *MockDetailedProbe_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDetailedProbe_Check_Call) Return(_a0 error) *MockDetailedProbe_Check_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDetailedProbe_Check_Call) RunAndReturn(run func(context.Context) error) *MockDetailedProbe_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckDetails provides a mock function with given fields: ctx
+func (_m *MockDetailedProbe) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckDetails")
+	}
+
+	var r0 map[string]interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]interface{}, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]interface{}); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDetailedProbe_CheckDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckDetails'
+type MockDetailedProbe_CheckDetails_Call struct {
+	*mock.Call
+}
+
+// CheckDetails is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDetailedProbe_Expecter) CheckDetails(ctx interface{}) *MockDetailedProbe_CheckDetails_Call {
+	return &MockDetailedProbe_CheckDetails_Call{Call: _e.mock.On("CheckDetails", ctx)}
+}
+
+func (_c *MockDetailedProbe_CheckDetails_Call) Run(run func(ctx context.Context)) *MockDetailedProbe_CheckDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDetailedProbe_CheckDetails_Call) Return(_a0 map[string]interface{}, _a1 error) *MockDetailedProbe_CheckDetails_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDetailedProbe_CheckDetails_Call) RunAndReturn(run func(context.Context) (map[string]interface{}, error)) *MockDetailedProbe_CheckDetails_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDetailedProbe creates a new instance of MockDetailedProbe. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDetailedProbe(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDetailedProbe {
+	mock := &MockDetailedProbe{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
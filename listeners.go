@@ -0,0 +1,135 @@
+package healthcheck
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// StatusListener is notified when the aggregated status changes.
+// The report the new status has been calculated from is provided;
+// its Group field tells which probe group the status belongs to.
+type StatusListener func(old, new Status, report Report)
+
+// ProbeStatusListener is notified when the status of a single probe changes.
+type ProbeStatusListener func(name string, old, new Status, result ProbeResult)
+
+// observe notifies the status listeners
+// if the aggregated status of the report's group has changed.
+func (hc *Healthcheck) observe(report Report) {
+	if len(hc.statusListeners) == 0 {
+		return
+	}
+
+	hc.statusesMu.Lock()
+	defer hc.statusesMu.Unlock()
+
+	if hc.statuses == nil {
+		hc.statuses = map[string]Status{}
+	}
+
+	previous, ok := hc.statuses[report.Group]
+	if !ok {
+		previous = StatusUnknown
+	}
+
+	if previous == report.Status {
+		return
+	}
+
+	hc.statuses[report.Group] = report.Status
+
+	for i, listener := range hc.statusListeners {
+		hc.statusNotifiers[i].notify(
+			func() {
+				listener(previous, report.Status, report.clone())
+			},
+		)
+	}
+}
+
+// notifyProbe notifies the probe status listeners about the probe status change.
+func (hc *Healthcheck) notifyProbe(name string, old Status, result ProbeResult) {
+	for i, listener := range hc.probeStatusListeners {
+		hc.probeStatusNotifiers[i].notify(
+			func() {
+				listener(name, old, result.Status, result)
+			},
+		)
+	}
+}
+
+// notifierQueueSize is the maximum number of pending notifications of a listener.
+const notifierQueueSize = 64
+
+// notifier runs the notifications of a single listener sequentially
+// in a separate goroutine, so slow listeners never block probe evaluation
+// nor delay the notifications of other listeners.
+// The goroutine is only alive while there are pending notifications.
+// If the listener falls behind by more than notifierQueueSize notifications,
+// the oldest pending ones are dropped.
+type notifier struct {
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	queue   []func()
+	running bool
+}
+
+func newNotifiers(count int, logger *slog.Logger) []*notifier {
+	notifiers := make([]*notifier, count)
+	for i := range notifiers {
+		notifiers[i] = &notifier{logger: logger}
+	}
+
+	return notifiers
+}
+
+func (n *notifier) notify(fn func()) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if len(n.queue) >= notifierQueueSize {
+		n.logger.Warn(
+			"healthcheck listener is too slow, dropping oldest notification",
+		)
+		n.queue = n.queue[1:]
+	}
+
+	n.queue = append(n.queue, fn)
+
+	if n.running {
+		return
+	}
+
+	n.running = true
+	go n.drain()
+}
+
+func (n *notifier) drain() {
+	for {
+		n.mu.Lock()
+
+		if len(n.queue) == 0 {
+			n.running = false
+			n.mu.Unlock()
+			return
+		}
+
+		fn := n.queue[0]
+		n.queue = n.queue[1:]
+
+		n.mu.Unlock()
+
+		n.run(fn)
+	}
+}
+
+func (n *notifier) run(fn func()) {
+	defer func() {
+		if err := recover(); err != nil {
+			n.logger.Error("healthcheck listener panicked", "panic", err)
+		}
+	}()
+
+	fn()
+}
@@ -0,0 +1,233 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nijeti/healthcheck/internal/generated/mocks"
+)
+
+type transition struct {
+	name     string
+	group    string
+	old, new Status
+}
+
+type transitions struct {
+	mu   sync.Mutex
+	list []transition
+}
+
+func (ts *transitions) add(t transition) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.list = append(ts.list, t)
+}
+
+func (ts *transitions) get() []transition {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	return append([]transition(nil), ts.list...)
+}
+
+func TestHealthcheck_StatusChangeListener(t *testing.T) {
+	t.Parallel()
+
+	probe := healthcheck.NewMockProbe(t)
+	probe.EXPECT().Check(mock.Anything).Return(nil).Twice()
+	probe.EXPECT().Check(mock.Anything).Return(errors.New("error")).Once()
+
+	statuses := &transitions{}
+	probeStatuses := &transitions{}
+
+	hc := New(
+		WithStatusChangeListener(
+			func(old, new Status, report Report) {
+				statuses.add(
+					transition{group: report.Group, old: old, new: new},
+				)
+			},
+		),
+		WithProbeStatusChangeListener(
+			func(name string, old, new Status, result ProbeResult) {
+				assert.Equal(t, new, result.Status)
+				probeStatuses.add(
+					transition{name: name, old: old, new: new},
+				)
+			},
+		),
+		WithProbe("probe", probe, ProbeGroups(GroupReadiness)),
+	)
+
+	ctx := context.Background()
+	hc.Handle(ctx)
+	hc.Handle(ctx)
+	hc.HandleGroup(ctx, GroupReadiness)
+
+	assert.Eventually(
+		t, func() bool {
+			return len(statuses.get()) == 2 && len(probeStatuses.get()) == 2
+		}, time.Second, time.Millisecond,
+	)
+
+	assert.Equal(
+		t, []transition{
+			{group: "", old: StatusUnknown, new: StatusHealthy},
+			{group: GroupReadiness, old: StatusUnknown, new: StatusUnhealthy},
+		}, statuses.get(),
+	)
+	assert.Equal(
+		t, []transition{
+			{name: "probe", old: StatusUnknown, new: StatusHealthy},
+			{name: "probe", old: StatusHealthy, new: StatusUnhealthy},
+		}, probeStatuses.get(),
+	)
+}
+
+func TestHealthcheck_StatusChangeListener_NonBlocking(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	calls := &transitions{}
+
+	hc := New(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithStatusChangeListener(
+			func(_, _ Status, _ Report) {
+				panic("listener panic")
+			},
+		),
+		WithStatusChangeListener(
+			func(old, new Status, _ Report) {
+				calls.add(transition{old: old, new: new})
+				<-release
+			},
+		),
+		WithSimpleProbe(
+			"probe", func(_ context.Context) error {
+				return nil
+			},
+		),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		hc.Handle(context.Background())
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		assert.Fail(t, "handle blocked by listener")
+	}
+
+	assert.Eventually(
+		t, func() bool {
+			return len(calls.get()) == 1
+		}, time.Second, time.Millisecond,
+	)
+}
+
+func TestHealthcheck_StatusChangeListener_Independent(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	calls := &transitions{}
+	fail := true
+
+	hc := New(
+		WithStatusChangeListener(
+			func(_, _ Status, _ Report) {
+				<-release
+			},
+		),
+		WithStatusChangeListener(
+			func(old, new Status, _ Report) {
+				calls.add(transition{old: old, new: new})
+			},
+		),
+		WithSimpleProbe(
+			"probe", func(_ context.Context) error {
+				fail = !fail
+				if fail {
+					return errors.New("probe error")
+				}
+
+				return nil
+			},
+		),
+	)
+
+	ctx := context.Background()
+	hc.Handle(ctx)
+	hc.Handle(ctx)
+
+	assert.Eventually(
+		t, func() bool {
+			return len(calls.get()) == 2
+		}, time.Second, time.Millisecond,
+	)
+	assert.Equal(
+		t, []transition{
+			{old: StatusUnknown, new: StatusHealthy},
+			{old: StatusHealthy, new: StatusUnhealthy},
+		}, calls.get(),
+	)
+}
+
+func TestNotifier_Bounded(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	n := &notifier{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	n.notify(
+		func() {
+			close(started)
+			<-release
+		},
+	)
+	<-started
+
+	var calls []int
+	for i := range notifierQueueSize * 2 {
+		n.notify(
+			func() {
+				calls = append(calls, i)
+			},
+		)
+	}
+
+	n.mu.Lock()
+	assert.Len(t, n.queue, notifierQueueSize)
+	n.mu.Unlock()
+
+	close(release)
+
+	assert.Eventually(
+		t, func() bool {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+
+			return !n.running
+		}, time.Second, time.Millisecond,
+	)
+	assert.Len(t, calls, notifierQueueSize)
+	assert.Equal(t, notifierQueueSize, calls[0])
+}
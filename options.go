@@ -1,19 +1,27 @@
 package healthcheck
 
 import (
-	"fmt"
 	"log/slog"
 	"time"
 )
 
 // Option configures a Healthcheck instance.
+// An option fails on invalid input,
+// which makes New panic and NewE return an error.
 type Option func(hc *Healthcheck)
 
+// failed returns an Option which fails with the given error.
+func failed(err error) Option {
+	return func(hc *Healthcheck) {
+		hc.optionErrs.fail(err)
+	}
+}
+
 // WithLogger sets the logger for the Healthcheck instance.
-// Panics if logger is nil.
+// Fails if logger is nil.
 func WithLogger(logger *slog.Logger) Option {
 	if logger == nil {
-		panic("healthcheck logger cannot be nil")
+		return failed(invalidOption("healthcheck logger cannot be nil"))
 	}
 
 	return func(hc *Healthcheck) {
@@ -21,38 +29,52 @@ func WithLogger(logger *slog.Logger) Option {
 	}
 }
 
-// WithProbe registers a new health check probe with the given name.
-// Panics if probe is nil or a probe with the same name already exists.
-func WithProbe(name string, probe Probe) Option {
+// WithProbe registers a new health check probe with the given name
+// and the provided probe options.
+// Fails if probe is nil, any probe option is invalid
+// or a probe with the same name already exists.
+func WithProbe(name string, probe Probe, opts ...ProbeOption) Option {
 	if probe == nil {
-		panic("healthcheck probe cannot be nil")
+		return failed(ErrNilProbe)
 	}
 
 	return func(hc *Healthcheck) {
 		if _, ok := hc.probes[name]; ok {
-			p := fmt.Sprintf("healthcheck probe '%s' already registered", name)
-			panic(p)
+			hc.optionErrs.fail(alreadyRegistered(name))
+			return
+		}
+
+		r, err := newRegistration(probe, opts)
+		if err != nil {
+			hc.optionErrs.fail(err)
+			return
 		}
 
-		hc.probes[name] = probe
+		hc.probes[name] = r
 	}
 }
 
-// WithSimpleProbe registers a simple health check probe under the specified name.
-// Panics if probe is nil or a probe with the same name already exists.
-func WithSimpleProbe(name string, probeFunc ProbeFunc) Option {
+// WithSimpleProbe registers a simple health check probe under the specified name
+// and with the provided probe options.
+// Fails if probe is nil, any probe option is invalid
+// or a probe with the same name already exists.
+func WithSimpleProbe(
+	name string, probeFunc ProbeFunc, opts ...ProbeOption,
+) Option {
 	if probeFunc == nil {
-		panic("healthcheck probe cannot be nil")
+		return failed(ErrNilProbe)
 	}
 
-	return WithProbe(name, &probe{check: probeFunc})
+	return WithProbe(name, &probe{check: probeFunc}, opts...)
 }
 
 // WithTimeoutDegraded sets the time after which a probe is considered degraded.
-// Panics if timeout is less than or equal to 0.
+// Fails if timeout is less than or equal to 0.
 func WithTimeoutDegraded(timeout time.Duration) Option {
 	if timeout <= 0 {
-		panic("healthcheck timeout must be greater than zero")
+		return failed(
+			invalidOption("healthcheck timeout must be greater than zero"),
+		)
 	}
 
 	return func(hc *Healthcheck) {
@@ -61,13 +83,258 @@ func WithTimeoutDegraded(timeout time.Duration) Option {
 }
 
 // WithTimeoutUnhealthy sets the time after which a probe is considered unhealthy.
-// Panics if timeout is less than or equal to 0.
+// Fails if timeout is less than or equal to 0.
 func WithTimeoutUnhealthy(timeout time.Duration) Option {
 	if timeout <= 0 {
-		panic("healthcheck timeout must be greater than zero")
+		return failed(
+			invalidOption("healthcheck timeout must be greater than zero"),
+		)
 	}
 
 	return func(hc *Healthcheck) {
 		hc.timeoutUnhealthy = timeout
 	}
 }
+
+// WithDeadlineGrace sets the time a probe is given to return
+// after its unhealthy timeout before it is abandoned and reported with
+// ErrProbeDeadlineExceeded.
+// The grace lets probes honouring their context report their own error,
+// while a grace of 0 abandons every probe at its unhealthy timeout.
+// A synchronous Handle returns within the longest unhealthy timeout
+// of the evaluated probes plus the grace.
+// Defaults to 1 second.
+// Fails if grace is negative.
+func WithDeadlineGrace(grace time.Duration) Option {
+	if grace < 0 {
+		return failed(
+			invalidOption("healthcheck deadline grace cannot be negative"),
+		)
+	}
+
+	return func(hc *Healthcheck) {
+		hc.deadlineGrace = grace
+	}
+}
+
+// WithInterval sets the default interval between probe runs
+// when background probing is started.
+// Fails if interval is less than or equal to 0.
+func WithInterval(interval time.Duration) Option {
+	if interval <= 0 {
+		return failed(
+			invalidOption("healthcheck interval must be greater than zero"),
+		)
+	}
+
+	return func(hc *Healthcheck) {
+		hc.interval = interval
+	}
+}
+
+// WithCacheTTL sets the time during which the result of the last evaluation
+// is reused instead of running the probes again.
+// Fails if ttl is less than or equal to 0.
+func WithCacheTTL(ttl time.Duration) Option {
+	if ttl <= 0 {
+		return failed(
+			invalidOption("healthcheck cache ttl must be greater than zero"),
+		)
+	}
+
+	return func(hc *Healthcheck) {
+		hc.cacheTTL = ttl
+	}
+}
+
+// WithStatusChangeListener registers a listener
+// which is notified when the aggregated status changes.
+// Every listener is run in its own goroutine, receiving its notifications in order,
+// so neither probe evaluation nor other listeners wait for it.
+// A listener which falls behind by more than 64 notifications
+// misses the oldest of them, so listeners should not block indefinitely.
+// Fails if listener is nil.
+func WithStatusChangeListener(listener StatusListener) Option {
+	if listener == nil {
+		return failed(
+			invalidOption("healthcheck status listener cannot be nil"),
+		)
+	}
+
+	return func(hc *Healthcheck) {
+		hc.statusListeners = append(hc.statusListeners, listener)
+	}
+}
+
+// WithProbeStatusChangeListener registers a listener
+// which is notified when the status of any probe changes.
+// Every listener is run in its own goroutine, receiving its notifications in order,
+// so neither probe evaluation nor other listeners wait for it.
+// A listener which falls behind by more than 64 notifications
+// misses the oldest of them, so listeners should not block indefinitely.
+// Fails if listener is nil.
+func WithProbeStatusChangeListener(listener ProbeStatusListener) Option {
+	if listener == nil {
+		return failed(
+			invalidOption("healthcheck status listener cannot be nil"),
+		)
+	}
+
+	return func(hc *Healthcheck) {
+		hc.probeStatusListeners = append(hc.probeStatusListeners, listener)
+	}
+}
+
+// WithHistorySize enables retaining of the given number of last results per probe.
+// Fails if size is less than or equal to 0.
+func WithHistorySize(size int) Option {
+	if size <= 0 {
+		return failed(
+			invalidOption("healthcheck history size must be greater than zero"),
+		)
+	}
+
+	return func(hc *Healthcheck) {
+		hc.historySize = size
+	}
+}
+
+// WithAggregator sets the strategy of calculating the aggregated status
+// from the results of probes.
+// Defaults to WorstWins.
+// Fails if aggregator is nil or has been built from invalid arguments.
+func WithAggregator(aggregator Aggregator) Option {
+	if aggregator == nil {
+		return failed(invalidOption("healthcheck aggregator cannot be nil"))
+	}
+
+	if a, ok := aggregator.(failedAggregator); ok {
+		return failed(a.err)
+	}
+
+	return func(hc *Healthcheck) {
+		hc.aggregator = aggregator
+	}
+}
+
+// WithStartupGracePeriod sets the period after the creation of the Healthcheck
+// during which failures of probes that have not succeeded yet
+// are reported as StatusStarting instead of StatusUnhealthy.
+// A probe leaves the starting state on its first success
+// or when the period is over, whichever comes first.
+// Fails if period is less than or equal to 0.
+func WithStartupGracePeriod(period time.Duration) Option {
+	if period <= 0 {
+		return failed(
+			invalidOption("healthcheck startup grace period must be greater than zero"),
+		)
+	}
+
+	return func(hc *Healthcheck) {
+		hc.startupPeriod = period
+	}
+}
+
+// ProbeOption configures a single probe registration.
+// A probe option fails on invalid input, which makes the probe registration fail.
+type ProbeOption func(r *registration)
+
+// probeFailed returns a ProbeOption which fails with the given error.
+func probeFailed(err error) ProbeOption {
+	return func(r *registration) {
+		r.optionErrs.fail(err)
+	}
+}
+
+// ProbeNonCritical marks the probe as non-critical.
+// Failure of a non-critical probe degrades the aggregated status at most.
+func ProbeNonCritical() ProbeOption {
+	return func(r *registration) {
+		r.critical = false
+	}
+}
+
+// ProbeGroups assigns the probe to the given groups.
+// Probes registered without groups belong to every group.
+// Fails if no groups are provided or any of them is empty.
+func ProbeGroups(groups ...string) ProbeOption {
+	if len(groups) == 0 {
+		return probeFailed(
+			invalidOption("healthcheck probe groups cannot be empty"),
+		)
+	}
+
+	for _, g := range groups {
+		if g == "" {
+			return probeFailed(
+				invalidOption("healthcheck probe group cannot be empty"),
+			)
+		}
+	}
+
+	return func(r *registration) {
+		if r.groups == nil {
+			r.groups = map[string]struct{}{}
+		}
+
+		for _, g := range groups {
+			r.groups[g] = struct{}{}
+		}
+	}
+}
+
+// ProbeTimeouts overrides the degradation and unhealthy timeouts for the probe.
+// Fails if any of the timeouts is less than or equal to 0
+// or degraded timeout is not less than unhealthy timeout.
+func ProbeTimeouts(degraded, unhealthy time.Duration) ProbeOption {
+	if degraded <= 0 || unhealthy <= 0 {
+		return probeFailed(
+			invalidOption("healthcheck timeout must be greater than zero"),
+		)
+	}
+
+	if degraded >= unhealthy {
+		return probeFailed(
+			invalidOption("healthcheck degradation timeout must be less than unhealthy timeout"),
+		)
+	}
+
+	return func(r *registration) {
+		r.timeoutDegraded = degraded
+		r.timeoutUnhealthy = unhealthy
+	}
+}
+
+// ProbeThresholds sets the number of consecutive failures
+// after which the probe is considered unhealthy
+// and the number of consecutive successes after which it recovers.
+// Failures below the threshold degrade the probe status.
+// Both thresholds default to 1.
+// Fails if any of the thresholds is less than 1.
+func ProbeThresholds(failure, success int) ProbeOption {
+	if failure < 1 || success < 1 {
+		return probeFailed(
+			invalidOption("healthcheck probe thresholds must be greater than zero"),
+		)
+	}
+
+	return func(r *registration) {
+		r.failureThreshold = failure
+		r.successThreshold = success
+	}
+}
+
+// ProbeInterval overrides the interval between probe runs
+// when background probing is started.
+// Fails if interval is less than or equal to 0.
+func ProbeInterval(interval time.Duration) ProbeOption {
+	if interval <= 0 {
+		return probeFailed(
+			invalidOption("healthcheck probe interval must be greater than zero"),
+		)
+	}
+
+	return func(r *registration) {
+		r.interval = interval
+	}
+}
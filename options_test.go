@@ -34,7 +34,7 @@ func TestWithProbe(t *testing.T) {
 	probe := healthcheck.NewMockProbe(t)
 
 	hc := &Healthcheck{
-		probes: map[string]Probe{},
+		probes: map[string]*registration{},
 	}
 
 	assert.PanicsWithValue(
@@ -44,7 +44,8 @@ func TestWithProbe(t *testing.T) {
 	)
 
 	WithProbe("probe", probe)(hc)
-	assert.Equal(t, probe, hc.probes["probe"])
+	assert.Equal(t, probe, hc.probes["probe"].probe)
+	assert.True(t, hc.probes["probe"].critical)
 
 	assert.PanicsWithValue(
 		t, "healthcheck probe 'probe' already registered",
@@ -62,7 +63,7 @@ func TestWithSimpleProbe(t *testing.T) {
 	}
 
 	hc := &Healthcheck{
-		probes: map[string]Probe{},
+		probes: map[string]*registration{},
 	}
 
 	assert.PanicsWithValue(
@@ -128,3 +129,248 @@ func TestWithTimeoutUnhealthy(t *testing.T) {
 	WithTimeoutUnhealthy(timeout)(hc)
 	assert.Equal(t, timeout, hc.timeoutUnhealthy)
 }
+
+func TestWithDeadlineGrace(t *testing.T) {
+	t.Parallel()
+
+	hc := &Healthcheck{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck deadline grace cannot be negative",
+		func() {
+			WithDeadlineGrace(-1)(hc)
+		},
+	)
+
+	WithDeadlineGrace(0)(hc)
+	assert.Zero(t, hc.deadlineGrace)
+
+	grace := 100 * time.Millisecond
+	WithDeadlineGrace(grace)(hc)
+	assert.Equal(t, grace, hc.deadlineGrace)
+}
+
+func TestProbeNonCritical(t *testing.T) {
+	t.Parallel()
+
+	r := &registration{critical: true}
+
+	ProbeNonCritical()(r)
+	assert.False(t, r.critical)
+}
+
+func TestProbeGroups(t *testing.T) {
+	t.Parallel()
+
+	r := &registration{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck probe groups cannot be empty",
+		func() {
+			ProbeGroups()(r)
+		},
+	)
+
+	assert.PanicsWithValue(
+		t, "healthcheck probe group cannot be empty",
+		func() {
+			ProbeGroups(GroupLiveness, "")(r)
+		},
+	)
+
+	assert.True(t, r.inGroup(GroupLiveness))
+
+	ProbeGroups(GroupLiveness, GroupReadiness)(r)
+	assert.True(t, r.inGroup(GroupLiveness))
+	assert.True(t, r.inGroup(GroupReadiness))
+	assert.False(t, r.inGroup(GroupStartup))
+}
+
+func TestWithInterval(t *testing.T) {
+	t.Parallel()
+
+	hc := &Healthcheck{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck interval must be greater than zero",
+		func() {
+			WithInterval(0)(hc)
+		},
+	)
+
+	interval := time.Duration(1)
+	WithInterval(interval)(hc)
+	assert.Equal(t, interval, hc.interval)
+}
+
+func TestProbeInterval(t *testing.T) {
+	t.Parallel()
+
+	r := &registration{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck probe interval must be greater than zero",
+		func() {
+			ProbeInterval(-1)(r)
+		},
+	)
+
+	interval := time.Duration(1)
+	ProbeInterval(interval)(r)
+	assert.Equal(t, interval, r.interval)
+}
+
+func TestWithCacheTTL(t *testing.T) {
+	t.Parallel()
+
+	hc := &Healthcheck{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck cache ttl must be greater than zero",
+		func() {
+			WithCacheTTL(0)(hc)
+		},
+	)
+
+	ttl := time.Duration(1)
+	WithCacheTTL(ttl)(hc)
+	assert.Equal(t, ttl, hc.cacheTTL)
+}
+
+func TestProbeTimeouts(t *testing.T) {
+	t.Parallel()
+
+	r := &registration{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck timeout must be greater than zero",
+		func() {
+			ProbeTimeouts(0, time.Second)(r)
+		},
+	)
+
+	assert.PanicsWithValue(
+		t, "healthcheck timeout must be greater than zero",
+		func() {
+			ProbeTimeouts(time.Second, -1)(r)
+		},
+	)
+
+	assert.PanicsWithValue(
+		t, "healthcheck degradation timeout must be less than unhealthy timeout",
+		func() {
+			ProbeTimeouts(time.Second, time.Second)(r)
+		},
+	)
+
+	ProbeTimeouts(time.Millisecond, time.Second)(r)
+	assert.Equal(t, time.Millisecond, r.timeoutDegraded)
+	assert.Equal(t, time.Second, r.timeoutUnhealthy)
+}
+
+func TestProbeThresholds(t *testing.T) {
+	t.Parallel()
+
+	r := &registration{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck probe thresholds must be greater than zero",
+		func() {
+			ProbeThresholds(0, 1)(r)
+		},
+	)
+
+	assert.PanicsWithValue(
+		t, "healthcheck probe thresholds must be greater than zero",
+		func() {
+			ProbeThresholds(1, 0)(r)
+		},
+	)
+
+	ProbeThresholds(3, 2)(r)
+	assert.Equal(t, 3, r.failureThreshold)
+	assert.Equal(t, 2, r.successThreshold)
+}
+
+func TestWithStatusChangeListener(t *testing.T) {
+	t.Parallel()
+
+	hc := &Healthcheck{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck status listener cannot be nil",
+		func() {
+			WithStatusChangeListener(nil)(hc)
+		},
+	)
+
+	WithStatusChangeListener(func(_, _ Status, _ Report) {})(hc)
+	assert.Len(t, hc.statusListeners, 1)
+}
+
+func TestWithProbeStatusChangeListener(t *testing.T) {
+	t.Parallel()
+
+	hc := &Healthcheck{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck status listener cannot be nil",
+		func() {
+			WithProbeStatusChangeListener(nil)(hc)
+		},
+	)
+
+	WithProbeStatusChangeListener(
+		func(_ string, _, _ Status, _ ProbeResult) {},
+	)(hc)
+	assert.Len(t, hc.probeStatusListeners, 1)
+}
+
+func TestWithHistorySize(t *testing.T) {
+	t.Parallel()
+
+	hc := &Healthcheck{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck history size must be greater than zero",
+		func() {
+			WithHistorySize(0)(hc)
+		},
+	)
+
+	WithHistorySize(10)(hc)
+	assert.Equal(t, 10, hc.historySize)
+}
+
+func TestWithAggregator(t *testing.T) {
+	t.Parallel()
+
+	hc := &Healthcheck{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck aggregator cannot be nil",
+		func() {
+			WithAggregator(nil)(hc)
+		},
+	)
+
+	aggregator := Quorum(1)
+	WithAggregator(aggregator)(hc)
+	assert.NotNil(t, hc.aggregator)
+}
+
+func TestWithStartupGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	hc := &Healthcheck{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck startup grace period must be greater than zero",
+		func() {
+			WithStartupGracePeriod(0)(hc)
+		},
+	)
+
+	WithStartupGracePeriod(time.Minute)(hc)
+	assert.Equal(t, time.Minute, hc.startupPeriod)
+}
@@ -0,0 +1,153 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Override represents a manually forced status of a Healthcheck.
+type Override struct {
+	// Status is the forced status.
+	Status Status
+
+	// Reason is the human-readable reason of the override.
+	Reason string
+
+	// ExpiresAt is the time the override expires at.
+	// Zero if the override never expires.
+	ExpiresAt time.Time
+}
+
+// SetOverride forces the aggregated status of the Healthcheck
+// regardless of the probe results, which are still evaluated and reported.
+// The override expires after ttl, or never if ttl is less than or equal to 0.
+// A subsequent call replaces the previous override.
+// Returns an error wrapping ErrInvalidStatus if status is not a known one.
+func (hc *Healthcheck) SetOverride(
+	status Status, reason string, ttl time.Duration,
+) error {
+	return hc.setOverride("", status, reason, ttl)
+}
+
+// ClearOverride removes the override set by SetOverride.
+func (hc *Healthcheck) ClearOverride() {
+	hc.clearOverride("")
+}
+
+// SetGroupOverride forces the aggregated status of the given probe group
+// regardless of the probe results, which are still evaluated and reported.
+// A group override takes precedence over the one set by SetOverride.
+// The override expires after ttl, or never if ttl is less than or equal to 0.
+// A subsequent call for the same group replaces the previous override.
+// Returns an error wrapping ErrInvalidStatus if status is not a known one.
+func (hc *Healthcheck) SetGroupOverride(
+	group string, status Status, reason string, ttl time.Duration,
+) error {
+	return hc.setOverride(group, status, reason, ttl)
+}
+
+// ClearGroupOverride removes the override set by SetGroupOverride for the given group.
+func (hc *Healthcheck) ClearGroupOverride(group string) {
+	hc.clearOverride(group)
+}
+
+// Drain makes the readiness group report unhealthy
+// and blocks for the given period, so traffic is drained from the application
+// while it keeps serving and the liveness group is not affected.
+// The readiness override is kept after Drain returns.
+// Returns the context error if ctx is done before the period is over.
+func (hc *Healthcheck) Drain(ctx context.Context, period time.Duration) error {
+	return hc.DrainGroup(ctx, GroupReadiness, period)
+}
+
+// DrainGroup makes the given probe group report unhealthy like Drain does
+// for the readiness group.
+// An empty group makes all probes report unhealthy.
+// The override is kept after DrainGroup returns.
+// Returns the context error if ctx is done before the period is over.
+func (hc *Healthcheck) DrainGroup(
+	ctx context.Context, group string, period time.Duration,
+) error {
+	_ = hc.setOverride(group, StatusUnhealthy, "draining", 0)
+
+	timer := time.NewTimer(period)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (hc *Healthcheck) setOverride(
+	group string, status Status, reason string, ttl time.Duration,
+) error {
+	if !status.valid() {
+		return fmt.Errorf("%w: %d", ErrInvalidStatus, status.Int())
+	}
+
+	o := Override{
+		Status: status,
+		Reason: reason,
+	}
+	if ttl > 0 {
+		o.ExpiresAt = time.Now().Add(ttl)
+	}
+
+	hc.overridesMu.Lock()
+	defer hc.overridesMu.Unlock()
+
+	if hc.overrides == nil {
+		hc.overrides = map[string]Override{}
+	}
+
+	hc.overrides[group] = o
+	return nil
+}
+
+func (hc *Healthcheck) clearOverride(group string) {
+	hc.overridesMu.Lock()
+	defer hc.overridesMu.Unlock()
+
+	delete(hc.overrides, group)
+}
+
+// override returns the active override for the given group
+// falling back to the override for all groups.
+// Expired overrides are removed.
+func (hc *Healthcheck) override(group string) (Override, bool) {
+	hc.overridesMu.Lock()
+	defer hc.overridesMu.Unlock()
+
+	for _, key := range []string{group, ""} {
+		o, ok := hc.overrides[key]
+		if !ok {
+			continue
+		}
+
+		if !o.ExpiresAt.IsZero() && !time.Now().Before(o.ExpiresAt) {
+			delete(hc.overrides, key)
+			continue
+		}
+
+		return o, true
+	}
+
+	return Override{}, false
+}
+
+// applyOverride sets the status of the report to the active override if any.
+func (hc *Healthcheck) applyOverride(report Report) Report {
+	o, ok := hc.override(report.Group)
+	if !ok {
+		return report
+	}
+
+	report.Status = o.Status
+	report.Override = &o
+
+	return report
+}
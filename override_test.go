@@ -0,0 +1,156 @@
+package healthcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nijeti/healthcheck/internal/generated/mocks"
+)
+
+func TestHealthcheck_SetOverride(t *testing.T) {
+	t.Parallel()
+
+	probe := healthcheck.NewMockProbe(t)
+	probe.EXPECT().Check(mock.Anything).Return(nil)
+
+	hc := New(WithProbe("probe", probe))
+	ctx := context.Background()
+
+	assert.ErrorIs(
+		t, hc.SetOverride(Status(7), "invalid", 0), ErrInvalidStatus,
+	)
+	assert.Nil(t, hc.HandleReport(ctx).Override)
+
+	assert.NoError(t, hc.SetOverride(StatusUnhealthy, "draining", 0))
+
+	report := hc.HandleReport(ctx)
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Equal(t, StatusHealthy, report.Probes["probe"].Status)
+	assert.Equal(
+		t, &Override{Status: StatusUnhealthy, Reason: "draining"},
+		report.Override,
+	)
+	assert.Equal(t, StatusUnhealthy, hc.HandleGroup(ctx, GroupReadiness))
+
+	assert.NoError(t, hc.SetOverride(StatusDegraded, "maintenance", time.Minute))
+
+	report = hc.HandleReport(ctx)
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, "maintenance", report.Override.Reason)
+	assert.WithinDuration(
+		t, time.Now().Add(time.Minute), report.Override.ExpiresAt, time.Second,
+	)
+
+	hc.ClearOverride()
+
+	report = hc.HandleReport(ctx)
+	assert.Equal(t, StatusHealthy, report.Status)
+	assert.Nil(t, report.Override)
+}
+
+func TestHealthcheck_SetOverride_Expired(t *testing.T) {
+	t.Parallel()
+
+	hc := New()
+	ctx := context.Background()
+
+	assert.NoError(
+		t, hc.SetOverride(StatusDegraded, "maintenance", 10*time.Millisecond),
+	)
+	assert.Equal(t, StatusDegraded, hc.Handle(ctx))
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, StatusUnknown, hc.Handle(ctx))
+	assert.Empty(t, hc.overrides)
+}
+
+func TestHealthcheck_Drain(t *testing.T) {
+	t.Parallel()
+
+	hc := New(
+		WithSimpleProbe(
+			"probe", func(_ context.Context) error {
+				return nil
+			},
+		),
+	)
+	ctx := context.Background()
+
+	drained := make(chan error)
+	go func() {
+		drained <- hc.Drain(ctx, 50*time.Millisecond)
+	}()
+
+	assert.Eventually(
+		t, func() bool {
+			return hc.HandleGroup(ctx, GroupReadiness) == StatusUnhealthy
+		}, time.Second, time.Millisecond,
+	)
+	assert.Equal(t, StatusHealthy, hc.HandleGroup(ctx, GroupLiveness))
+	assert.Equal(t, StatusHealthy, hc.Handle(ctx))
+
+	assert.NoError(t, <-drained)
+	assert.Equal(t, StatusUnhealthy, hc.HandleGroup(ctx, GroupReadiness))
+
+	hc.ClearGroupOverride(GroupReadiness)
+	assert.Equal(t, StatusHealthy, hc.HandleGroup(ctx, GroupReadiness))
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, hc.Drain(cancelled, time.Minute), context.Canceled)
+}
+
+func TestHealthcheck_DrainGroup(t *testing.T) {
+	t.Parallel()
+
+	hc := New(
+		WithSimpleProbe(
+			"probe", func(_ context.Context) error {
+				return nil
+			},
+		),
+	)
+	ctx := context.Background()
+
+	assert.NoError(t, hc.DrainGroup(ctx, "ready", time.Millisecond))
+	assert.Equal(t, StatusUnhealthy, hc.HandleGroup(ctx, "ready"))
+	assert.Equal(t, StatusHealthy, hc.HandleGroup(ctx, GroupReadiness))
+	assert.Equal(t, StatusHealthy, hc.Handle(ctx))
+
+	assert.NoError(t, hc.DrainGroup(ctx, "", time.Millisecond))
+	assert.Equal(t, StatusUnhealthy, hc.Handle(ctx))
+	assert.Equal(t, StatusUnhealthy, hc.HandleGroup(ctx, GroupLiveness))
+}
+
+func TestHealthcheck_SetGroupOverride(t *testing.T) {
+	t.Parallel()
+
+	hc := New(
+		WithSimpleProbe(
+			"probe", func(_ context.Context) error {
+				return nil
+			},
+		),
+	)
+	ctx := context.Background()
+
+	assert.NoError(t, hc.SetOverride(StatusDegraded, "maintenance", 0))
+	assert.NoError(
+		t, hc.SetGroupOverride(GroupReadiness, StatusUnhealthy, "draining", 0),
+	)
+	assert.ErrorIs(
+		t, hc.SetGroupOverride(GroupReadiness, Status(-2), "invalid", 0),
+		ErrInvalidStatus,
+	)
+
+	assert.Equal(t, StatusUnhealthy, hc.HandleGroup(ctx, GroupReadiness))
+	assert.Equal(t, StatusDegraded, hc.HandleGroup(ctx, GroupLiveness))
+	assert.Equal(t, StatusDegraded, hc.Handle(ctx))
+
+	hc.ClearGroupOverride(GroupReadiness)
+	assert.Equal(t, StatusDegraded, hc.HandleGroup(ctx, GroupReadiness))
+}
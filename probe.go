@@ -2,14 +2,42 @@ package healthcheck
 
 import (
 	"context"
+	"sync"
+	"time"
 )
 
 // Probe defines an interface for performing health checks.
 type Probe interface {
 	// Check performs a health check and returns an error if the check fails.
+	// A StatusError can be returned to report a specific status explicitly.
 	Check(ctx context.Context) error
 }
 
+// DetailedProbe defines an interface for performing health checks
+// which also report details describing their result,
+// such as connection pool usage or remote service version.
+type DetailedProbe interface {
+	Probe
+
+	// CheckDetails performs a health check like Check
+	// and additionally returns details describing the result.
+	// It is called instead of Check when the probe is run.
+	CheckDetails(ctx context.Context) (map[string]any, error)
+}
+
+// ReportingProbe defines an interface for performing health checks
+// which are based on a nested health check report,
+// such as the Healthcheck of a subsystem.
+type ReportingProbe interface {
+	Probe
+
+	// CheckReport performs a health check like Check
+	// and additionally returns the report the result is based on.
+	// The report is nil if the check has failed before obtaining it.
+	// It is called instead of Check when the probe is run.
+	CheckReport(ctx context.Context) (*Report, error)
+}
+
 // ProbeFunc defines a function type for performing a health check.
 type ProbeFunc func(ctx context.Context) error
 
@@ -20,3 +48,219 @@ type probe struct {
 func (p *probe) Check(ctx context.Context) error {
 	return p.check(ctx)
 }
+
+// Well-known probe groups matching the Kubernetes probe kinds.
+const (
+	GroupLiveness  = "liveness"
+	GroupReadiness = "readiness"
+	GroupStartup   = "startup"
+)
+
+type registration struct {
+	probe    Probe
+	critical bool
+	groups   map[string]struct{}
+	interval time.Duration
+
+	timeoutDegraded  time.Duration
+	timeoutUnhealthy time.Duration
+
+	failureThreshold int
+	successThreshold int
+
+	// cancel stops background probing of the probe.
+	// Guarded by the lifecycle mutex of Healthcheck.
+	cancel context.CancelFunc
+
+	// optionErrs collects the errors of the probe options on registration.
+	optionErrs optionErrors
+
+	mu        sync.Mutex
+	last      *ProbeResult
+	history   *history
+	abandoned int
+	failures  int
+	successes int
+	failing   bool
+	succeeded bool
+}
+
+// probeRun represents a single run of a probe.
+type probeRun struct {
+	// outcome is buffered, so an abandoned run never blocks on sending to it.
+	outcome chan probeOutcome
+
+	// abandoned is guarded by the mutex of the registration.
+	abandoned bool
+}
+
+type probeOutcome struct {
+	err      error
+	details  map[string]any
+	report   *Report
+	panic    any
+	panicked bool
+}
+
+func newRegistration(
+	probe Probe, opts []ProbeOption,
+) (*registration, error) {
+	r := &registration{
+		probe:            probe,
+		critical:         true,
+		failureThreshold: 1,
+		successThreshold: 1,
+		optionErrs:       optionErrors{collecting: true},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	err := r.optionErrs.err()
+	r.optionErrs = optionErrors{}
+
+	return r, err
+}
+
+// inGroup reports whether the probe belongs to the given group.
+// Probes registered without groups belong to every group.
+func (r *registration) inGroup(group string) bool {
+	if len(r.groups) == 0 {
+		return true
+	}
+
+	_, ok := r.groups[group]
+	return ok
+}
+
+// lastResult returns the last known result of the probe
+// or a result with StatusUnknown if the probe has not completed yet.
+func (r *registration) lastResult() ProbeResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.last == nil {
+		return ProbeResult{
+			Status:   StatusUnknown,
+			Critical: r.critical,
+		}
+	}
+
+	return *r.last
+}
+
+// record applies the failure and success thresholds to the result of a probe run
+// and stores it as the last known result.
+// A probe becomes unhealthy after failureThreshold consecutive failures
+// and recovers after successThreshold consecutive successes.
+// Failures below the threshold are reported as degraded.
+// Until startupDeadline, failures of a probe which has never succeeded
+// are reported as starting.
+// A starting status reported by the probe itself,
+// such as by a nested Healthcheck, is neither a failure nor a success
+// and is kept as is.
+// The status of the previous known result is returned along with the result.
+func (r *registration) record(
+	result ProbeResult, startupDeadline time.Time,
+) (ProbeResult, Status) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := StatusUnknown
+	if r.last != nil {
+		previous = r.last.Status
+	}
+
+	reportedStarting := result.Status == StatusStarting
+	failed := result.Status == StatusUnhealthy
+
+	switch {
+	case reportedStarting:
+	case failed:
+		r.failures++
+		r.successes = 0
+
+		if r.failures >= r.failureThreshold {
+			r.failing = true
+		}
+	default:
+		r.successes++
+		r.failures = 0
+		r.succeeded = true
+
+		if r.successes >= r.successThreshold {
+			r.failing = false
+		}
+	}
+
+	result.ConsecutiveFailures = r.failures
+	result.ConsecutiveSuccesses = r.successes
+
+	switch {
+	case reportedStarting:
+	case r.failing:
+		result.Status = StatusUnhealthy
+	case result.Status == StatusUnhealthy:
+		result.Status = StatusDegraded
+	}
+
+	if failed && !r.succeeded && time.Now().Before(startupDeadline) {
+		result.Status = StatusStarting
+	}
+
+	r.last = &result
+	r.history.add(result)
+
+	return result, previous
+}
+
+// check runs the probe and sends its outcome, including a panic, to the run.
+func (r *registration) check(ctx context.Context, run *probeRun) {
+	defer func() {
+		if p := recover(); p != nil {
+			run.outcome <- probeOutcome{panic: p, panicked: true}
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if run.abandoned {
+			r.abandoned--
+		}
+	}()
+
+	if rp, ok := r.probe.(ReportingProbe); ok {
+		report, err := rp.CheckReport(ctx)
+		run.outcome <- probeOutcome{err: err, report: report}
+		return
+	}
+
+	if dp, ok := r.probe.(DetailedProbe); ok {
+		details, err := dp.CheckDetails(ctx)
+		run.outcome <- probeOutcome{err: err, details: details}
+		return
+	}
+
+	run.outcome <- probeOutcome{err: r.probe.Check(ctx)}
+}
+
+// abandon marks the run as abandoned unless its outcome is already available.
+func (r *registration) abandon(run *probeRun) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(run.outcome) == 0 {
+		run.abandoned = true
+		r.abandoned++
+	}
+}
+
+// isAbandoned reports whether any run of the probe
+// has exceeded its deadline or been interrupted and not returned since.
+func (r *registration) isAbandoned() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.abandoned > 0
+}
@@ -0,0 +1,201 @@
+//go:build linux
+
+package probes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/nijeti/healthcheck"
+)
+
+// DiskProbe is a probe checking the free space and inodes
+// of the filesystem a path resides on,
+// and optionally that the path is writable.
+// The filesystem statistics are reported as the details of the result.
+type DiskProbe struct {
+	path     string
+	writable bool
+
+	degradedBytes  uint64
+	unhealthyBytes uint64
+
+	degradedPercent  float64
+	unhealthyPercent float64
+
+	degradedInodes  uint64
+	unhealthyInodes uint64
+}
+
+// DiskOption configures a DiskProbe instance.
+type DiskOption func(p *DiskProbe)
+
+// NewDisk creates a new DiskProbe checking the given path
+// with the provided options.
+// By default, only the filesystem statistics are checked to be readable.
+func NewDisk(path string, opts ...DiskOption) *DiskProbe {
+	p := &DiskProbe{
+		path: path,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// DiskMinFreeBytes sets the number of bytes available to unprivileged users
+// below which the probe is degraded or unhealthy.
+// The degraded threshold is expected to be greater than the unhealthy one,
+// otherwise it has no effect, since being unhealthy takes precedence.
+// A threshold of 0 disables the respective check.
+func DiskMinFreeBytes(degraded, unhealthy uint64) DiskOption {
+	return func(p *DiskProbe) {
+		p.degradedBytes = degraded
+		p.unhealthyBytes = unhealthy
+	}
+}
+
+// DiskMinFreePercent sets the percentage of blocks available to unprivileged users
+// below which the probe is degraded or unhealthy.
+// The degraded threshold is expected to be greater than the unhealthy one,
+// otherwise it has no effect, since being unhealthy takes precedence.
+// A threshold of 0 disables the respective check.
+func DiskMinFreePercent(degraded, unhealthy float64) DiskOption {
+	return func(p *DiskProbe) {
+		p.degradedPercent = degraded
+		p.unhealthyPercent = unhealthy
+	}
+}
+
+// DiskMinFreeInodes sets the number of free inodes
+// below which the probe is degraded or unhealthy.
+// The degraded threshold is expected to be greater than the unhealthy one,
+// otherwise it has no effect, since being unhealthy takes precedence.
+// The check is skipped for filesystems which do not report inodes.
+// A threshold of 0 disables the respective check.
+func DiskMinFreeInodes(degraded, unhealthy uint64) DiskOption {
+	return func(p *DiskProbe) {
+		p.degradedInodes = degraded
+		p.unhealthyInodes = unhealthy
+	}
+}
+
+// DiskWritable makes the probe verify that the path is a writable directory
+// by creating, syncing and removing a temporary file in it.
+func DiskWritable() DiskOption {
+	return func(p *DiskProbe) {
+		p.writable = true
+	}
+}
+
+// Check inspects the filesystem of the path
+// and verifies it is writable if configured.
+func (p *DiskProbe) Check(ctx context.Context) error {
+	_, err := p.CheckDetails(ctx)
+	return err
+}
+
+// CheckDetails checks the path like Check
+// and additionally returns the statistics of its filesystem.
+func (p *DiskProbe) CheckDetails(ctx context.Context) (map[string]any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(p.path, &stat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat filesystem: %w", err)
+	}
+
+	freeBytes := stat.Bavail * uint64(stat.Frsize)
+	freePercent := 100.0
+	if stat.Blocks > 0 {
+		freePercent = float64(stat.Bavail) / float64(stat.Blocks) * 100
+	}
+
+	details := map[string]any{
+		"free_bytes":   freeBytes,
+		"total_bytes":  stat.Blocks * uint64(stat.Frsize),
+		"free_percent": freePercent,
+		"free_inodes":  stat.Ffree,
+		"total_inodes": stat.Files,
+	}
+
+	var unhealthy, degraded []string
+
+	if p.unhealthyBytes > 0 && freeBytes < p.unhealthyBytes {
+		unhealthy = append(
+			unhealthy, fmt.Sprintf("%d bytes free", freeBytes),
+		)
+	} else if p.degradedBytes > 0 && freeBytes < p.degradedBytes {
+		degraded = append(degraded, fmt.Sprintf("%d bytes free", freeBytes))
+	}
+
+	if p.unhealthyPercent > 0 && freePercent < p.unhealthyPercent {
+		unhealthy = append(
+			unhealthy, fmt.Sprintf("%.2f%% free", freePercent),
+		)
+	} else if p.degradedPercent > 0 && freePercent < p.degradedPercent {
+		degraded = append(degraded, fmt.Sprintf("%.2f%% free", freePercent))
+	}
+
+	if stat.Files > 0 {
+		if p.unhealthyInodes > 0 && stat.Ffree < p.unhealthyInodes {
+			unhealthy = append(
+				unhealthy, fmt.Sprintf("%d inodes free", stat.Ffree),
+			)
+		} else if p.degradedInodes > 0 && stat.Ffree < p.degradedInodes {
+			degraded = append(
+				degraded, fmt.Sprintf("%d inodes free", stat.Ffree),
+			)
+		}
+	}
+
+	if p.writable {
+		err = p.checkWritable()
+		if err != nil {
+			return details, err
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		return details, fmt.Errorf(
+			"%w: %s", ErrLowDiskSpace, strings.Join(unhealthy, ", "),
+		)
+	}
+
+	if len(degraded) > 0 {
+		return details, healthcheck.Degraded(
+			"disk space is low: " + strings.Join(degraded, ", "),
+		)
+	}
+
+	return details, nil
+}
+
+// checkWritable creates, syncs and removes a temporary file in the path.
+func (p *DiskProbe) checkWritable() error {
+	f, err := os.CreateTemp(p.path, ".healthcheck-*")
+	if err != nil {
+		return fmt.Errorf("path is not writable: %w", err)
+	}
+
+	_, err = f.Write([]byte{0})
+	if err == nil {
+		err = f.Sync()
+	}
+
+	err = errors.Join(err, f.Close(), os.Remove(f.Name()))
+	if err != nil {
+		return fmt.Errorf("path is not writable: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+//go:build linux
+
+package probes
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskProbe_Check(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	file := filepath.Join(dir, "file")
+	err := os.WriteFile(file, nil, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(
+		func() {
+			entries, err := os.ReadDir(dir)
+			assert.NoError(t, err)
+			assert.Len(t, entries, 1, "temporary file is left behind")
+		},
+	)
+
+	var stat syscall.Statfs_t
+	err = syscall.Statfs(dir, &stat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		path     string
+		opts     []DiskOption
+		inodes   bool
+		err      error
+		degraded string
+		fails    bool
+	}{
+		"stats": {
+			path: dir,
+		},
+		"thresholds": {
+			path: dir,
+			opts: []DiskOption{
+				DiskMinFreeBytes(1, 1),
+				DiskMinFreePercent(0.0001, 0.0001),
+			},
+		},
+		"degraded bytes": {
+			path:     dir,
+			opts:     []DiskOption{DiskMinFreeBytes(math.MaxUint64, 1)},
+			degraded: "bytes free",
+		},
+		"unhealthy bytes": {
+			path: dir,
+			opts: []DiskOption{
+				DiskMinFreeBytes(math.MaxUint64, math.MaxUint64),
+			},
+			err: ErrLowDiskSpace,
+		},
+		"degraded percent": {
+			path:     dir,
+			opts:     []DiskOption{DiskMinFreePercent(101, 0)},
+			degraded: "% free",
+		},
+		"unhealthy percent": {
+			path: dir,
+			opts: []DiskOption{DiskMinFreePercent(0, 101)},
+			err:  ErrLowDiskSpace,
+		},
+		"degraded inodes": {
+			path:     dir,
+			opts:     []DiskOption{DiskMinFreeInodes(math.MaxUint64, 1)},
+			inodes:   true,
+			degraded: "inodes free",
+		},
+		"unhealthy inodes": {
+			path: dir,
+			opts: []DiskOption{
+				DiskMinFreeInodes(math.MaxUint64, math.MaxUint64),
+			},
+			inodes: true,
+			err:    ErrLowDiskSpace,
+		},
+		"misordered thresholds": {
+			path: dir,
+			opts: []DiskOption{
+				DiskMinFreeBytes(1, math.MaxUint64),
+			},
+			err: ErrLowDiskSpace,
+		},
+		"writable": {
+			path: dir,
+			opts: []DiskOption{DiskWritable()},
+		},
+		"not writable": {
+			path:  file,
+			opts:  []DiskOption{DiskWritable()},
+			fails: true,
+		},
+		"missing path": {
+			path:  filepath.Join(dir, "missing"),
+			fails: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				if tt.inodes && stat.Files == 0 {
+					t.Skip("filesystem does not report inodes")
+				}
+
+				details, err := NewDisk(tt.path, tt.opts...).
+					CheckDetails(context.Background())
+
+				switch {
+				case tt.err != nil:
+					assert.ErrorIs(t, err, tt.err)
+				case tt.degraded != "":
+					assertDegraded(t, err, tt.degraded)
+				case tt.fails:
+					assert.Error(t, err)
+					return
+				default:
+					assert.NoError(t, err)
+				}
+
+				assert.Contains(t, details, "free_bytes")
+				assert.Contains(t, details, "free_percent")
+				assert.Contains(t, details, "free_inodes")
+				assert.Equal(
+					t, stat.Blocks*uint64(stat.Frsize), details["total_bytes"],
+				)
+			},
+		)
+	}
+}
@@ -0,0 +1,15 @@
+package probes
+
+import (
+	"errors"
+)
+
+var (
+	// ErrUnexpectedResponse is returned when a probed service
+	// responds differently than expected.
+	ErrUnexpectedResponse = errors.New("probe received unexpected response")
+
+	// ErrLowDiskSpace is returned when the free space or inodes of a filesystem
+	// are below the unhealthy threshold.
+	ErrLowDiskSpace = errors.New("disk space is low")
+)
@@ -0,0 +1,170 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maxBodySize is the maximum number of bytes of the response body
+// an HTTPProbe inspects.
+const maxBodySize = 1 << 20
+
+// HTTPProbe is a probe checking that an HTTP endpoint responds as expected.
+type HTTPProbe struct {
+	client  *http.Client
+	method  string
+	url     string
+	codes   []int
+	body    string
+	bodyRe  *regexp.Regexp
+	headers map[string]string
+}
+
+// HTTPOption configures an HTTPProbe instance.
+type HTTPOption func(p *HTTPProbe)
+
+// NewHTTP creates a new HTTPProbe requesting the given URL
+// with the provided options.
+// By default, a GET request is performed with http.DefaultClient
+// and any 2xx status code is expected.
+// The request is bound to the context of the check.
+func NewHTTP(url string, opts ...HTTPOption) *HTTPProbe {
+	p := &HTTPProbe{
+		client:  http.DefaultClient,
+		method:  http.MethodGet,
+		url:     url,
+		headers: map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// HTTPClient sets the client the probe performs the request with,
+// such as one configured for mTLS or a proxy.
+// A nil client is ignored.
+func HTTPClient(client *http.Client) HTTPOption {
+	return func(p *HTTPProbe) {
+		if client != nil {
+			p.client = client
+		}
+	}
+}
+
+// HTTPMethod sets the method of the request.
+func HTTPMethod(method string) HTTPOption {
+	return func(p *HTTPProbe) {
+		p.method = method
+	}
+}
+
+// HTTPStatusCodes sets the status codes the response is expected to have
+// instead of any 2xx one.
+func HTTPStatusCodes(codes ...int) HTTPOption {
+	return func(p *HTTPProbe) {
+		p.codes = codes
+	}
+}
+
+// HTTPBodyContains makes the probe expect the response body
+// to contain the given substring.
+func HTTPBodyContains(substr string) HTTPOption {
+	return func(p *HTTPProbe) {
+		p.body = substr
+	}
+}
+
+// HTTPBodyMatches makes the probe expect the response body
+// to match the given regular expression.
+func HTTPBodyMatches(re *regexp.Regexp) HTTPOption {
+	return func(p *HTTPProbe) {
+		p.bodyRe = re
+	}
+}
+
+// HTTPHeader makes the probe expect the response to have the given header.
+// If value is empty, only the presence of the header is checked.
+func HTTPHeader(name, value string) HTTPOption {
+	return func(p *HTTPProbe) {
+		p.headers[name] = value
+	}
+}
+
+// Check performs the request and verifies the response.
+func (p *HTTPProbe) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, p.method, p.url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// The unread rest of the body is drained,
+		// so that the client can reuse the connection.
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodySize))
+		_ = resp.Body.Close()
+	}()
+
+	if !p.expectedCode(resp.StatusCode) {
+		return fmt.Errorf(
+			"%w: status code %d", ErrUnexpectedResponse, resp.StatusCode,
+		)
+	}
+
+	for name, value := range p.headers {
+		values := resp.Header.Values(name)
+		if len(values) == 0 {
+			return fmt.Errorf(
+				"%w: missing header %q", ErrUnexpectedResponse, name,
+			)
+		}
+
+		if value != "" && !slices.Contains(values, value) {
+			return fmt.Errorf(
+				"%w: header %q is %q", ErrUnexpectedResponse, name, values,
+			)
+		}
+	}
+
+	if p.body == "" && p.bodyRe == nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if p.body != "" && !strings.Contains(string(body), p.body) {
+		return fmt.Errorf(
+			"%w: body does not contain %q", ErrUnexpectedResponse, p.body,
+		)
+	}
+
+	if p.bodyRe != nil && !p.bodyRe.Match(body) {
+		return fmt.Errorf(
+			"%w: body does not match %q", ErrUnexpectedResponse, p.bodyRe,
+		)
+	}
+
+	return nil
+}
+
+func (p *HTTPProbe) expectedCode(code int) bool {
+	if len(p.codes) == 0 {
+		return code >= http.StatusOK && code < http.StatusMultipleChoices
+	}
+
+	return slices.Contains(p.codes, code)
+}
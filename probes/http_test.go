@@ -0,0 +1,173 @@
+package probes
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPProbe_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opts    []HTTPOption
+		handler http.HandlerFunc
+		err     error
+		fails   bool
+	}{
+		"ok": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+		},
+		"unexpected code": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			err: ErrUnexpectedResponse,
+		},
+		"expected code": {
+			opts: []HTTPOption{HTTPStatusCodes(http.StatusUnauthorized)},
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+		},
+		"method": {
+			opts: []HTTPOption{HTTPMethod(http.MethodHead)},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodHead {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+				}
+			},
+		},
+		"body contains": {
+			opts: []HTTPOption{HTTPBodyContains("pong")},
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("ping pong"))
+			},
+		},
+		"body does not contain": {
+			opts: []HTTPOption{HTTPBodyContains("pong")},
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("ping"))
+			},
+			err: ErrUnexpectedResponse,
+		},
+		"body matches": {
+			opts: []HTTPOption{
+				HTTPBodyMatches(regexp.MustCompile(`"version":\s*"v\d+`)),
+			},
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"version": "v2.1.0"}`))
+			},
+		},
+		"body does not match": {
+			opts: []HTTPOption{
+				HTTPBodyMatches(regexp.MustCompile(`^ok$`)),
+			},
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("not ok"))
+			},
+			err: ErrUnexpectedResponse,
+		},
+		"header": {
+			opts: []HTTPOption{
+				HTTPHeader("Content-Type", "application/json"),
+				HTTPHeader("X-Request-Id", ""),
+			},
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("X-Request-Id", "42")
+			},
+		},
+		"missing header": {
+			opts:    []HTTPOption{HTTPHeader("X-Request-Id", "")},
+			handler: func(_ http.ResponseWriter, _ *http.Request) {},
+			err:     ErrUnexpectedResponse,
+		},
+		"header value": {
+			opts: []HTTPOption{HTTPHeader("Content-Type", "application/json")},
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+			},
+			err: ErrUnexpectedResponse,
+		},
+		"timeout": {
+			handler: func(_ http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			},
+			fails: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				server := httptest.NewServer(tt.handler)
+				t.Cleanup(server.Close)
+
+				ctx, cancel := context.WithTimeout(
+					context.Background(), 100*time.Millisecond,
+				)
+				defer cancel()
+
+				opts := append([]HTTPOption{HTTPClient(server.Client())}, tt.opts...)
+				err := NewHTTP(server.URL, opts...).Check(ctx)
+
+				switch {
+				case tt.err != nil:
+					assert.ErrorIs(t, err, tt.err)
+				case tt.fails:
+					assert.Error(t, err)
+				default:
+					assert.NoError(t, err)
+				}
+			},
+		)
+	}
+}
+
+func TestHTTPProbe_Check_InvalidURL(t *testing.T) {
+	t.Parallel()
+
+	assert.Error(t, NewHTTP("://invalid").Check(context.Background()))
+}
+
+func TestHTTPProbe_Check_ReusesConnection(t *testing.T) {
+	t.Parallel()
+
+	connections := atomic.Int32{}
+
+	server := httptest.NewUnstartedServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(strings.Repeat("ok", 256<<10)))
+			},
+		),
+	)
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	probe := NewHTTP(server.URL, HTTPClient(server.Client()))
+
+	for range 3 {
+		assert.NoError(t, probe.Check(context.Background()))
+	}
+
+	assert.Equal(t, int32(1), connections.Load())
+}
@@ -0,0 +1,3 @@
+// Package probes provides ready-made probes for common dependencies,
+// which can be registered with healthcheck.WithProbe.
+package probes
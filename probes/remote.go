@@ -0,0 +1,113 @@
+package probes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/nijeti/healthcheck"
+)
+
+// RemoteProbe is a probe checking the health of a remote service
+// which exposes its health check with one of the servers of this library.
+// It maps the status served by the remote service back into a healthcheck.Status,
+// so that a degraded remote service degrades the probe
+// rather than being considered healthy.
+//
+// The URL can point either to a status route, which serves the status as text,
+// or to a report route, which serves the detailed report as JSON.
+// In the latter case, the remote report is nested into the result of the probe.
+type RemoteProbe struct {
+	client *http.Client
+	url    string
+}
+
+// RemoteOption configures a RemoteProbe instance.
+type RemoteOption func(p *RemoteProbe)
+
+// NewRemote creates a new RemoteProbe requesting the given URL
+// with the provided options.
+// By default, the request is performed with http.DefaultClient.
+// The request is bound to the context of the check.
+func NewRemote(url string, opts ...RemoteOption) *RemoteProbe {
+	p := &RemoteProbe{
+		client: http.DefaultClient,
+		url:    url,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// RemoteClient sets the client the probe performs the request with.
+// A nil client is ignored.
+func RemoteClient(client *http.Client) RemoteOption {
+	return func(p *RemoteProbe) {
+		if client != nil {
+			p.client = client
+		}
+	}
+}
+
+// Check requests the remote health check and returns an error
+// unless the remote status is healthy.
+// A degraded remote status is reported as a healthcheck.StatusError
+// with healthcheck.StatusDegraded.
+func (p *RemoteProbe) Check(ctx context.Context) error {
+	_, err := p.CheckReport(ctx)
+	return err
+}
+
+// CheckReport requests the remote health check like Check
+// and additionally returns the remote report.
+// If the remote service serves the status as text,
+// the report contains the status only.
+// The report is nil if the request fails or the response is unexpected.
+func (p *RemoteProbe) CheckReport(
+	ctx context.Context,
+) (*healthcheck.Report, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	report := healthcheck.Report{
+		Status: healthcheck.StatusUnknown,
+		Probes: map[string]healthcheck.ProbeResult{},
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		err = json.Unmarshal(body, &report)
+	} else {
+		report.Status, err = healthcheck.ParseStatus(
+			strings.TrimSpace(string(body)),
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: status code %d: %w",
+			ErrUnexpectedResponse, resp.StatusCode, err,
+		)
+	}
+
+	return &report, report.Err()
+}
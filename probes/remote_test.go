@@ -0,0 +1,195 @@
+package probes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nijeti/healthcheck"
+)
+
+func TestRemoteProbe_CheckReport(t *testing.T) {
+	t.Parallel()
+
+	remote := healthcheck.New(
+		healthcheck.WithSimpleProbe(
+			"database", func(_ context.Context) error {
+				return healthcheck.Degraded("replica lag")
+			},
+		),
+	)
+	remoteReport, err := json.Marshal(
+		remote.HandleReport(context.Background()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		code        int
+		contentType string
+		body        string
+		status      healthcheck.Status
+		probes      []string
+		err         error
+	}{
+		"healthy": {
+			code:   http.StatusOK,
+			body:   "healthy",
+			status: healthcheck.StatusHealthy,
+		},
+		"degraded": {
+			code:   http.StatusOK,
+			body:   "degraded",
+			status: healthcheck.StatusDegraded,
+		},
+		"unhealthy": {
+			code:   http.StatusServiceUnavailable,
+			body:   "unhealthy",
+			status: healthcheck.StatusUnhealthy,
+		},
+		"starting": {
+			code:   http.StatusServiceUnavailable,
+			body:   "starting",
+			status: healthcheck.StatusStarting,
+		},
+		"report": {
+			code:        http.StatusOK,
+			contentType: "application/json",
+			body:        string(remoteReport),
+			status:      healthcheck.StatusDegraded,
+			probes:      []string{"database"},
+		},
+		"unexpected body": {
+			code: http.StatusOK,
+			body: "OK",
+			err:  ErrUnexpectedResponse,
+		},
+		"report without status": {
+			code:        http.StatusOK,
+			contentType: "application/json",
+			body:        `{"probe": []}`,
+			err:         ErrUnexpectedResponse,
+		},
+		"invalid report": {
+			code:        http.StatusOK,
+			contentType: "application/json",
+			body:        `{"status": "fine"}`,
+			err:         ErrUnexpectedResponse,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				server := httptest.NewServer(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, _ *http.Request) {
+							if tt.contentType != "" {
+								w.Header().Set("Content-Type", tt.contentType)
+							}
+							w.WriteHeader(tt.code)
+							_, _ = w.Write([]byte(tt.body))
+						},
+					),
+				)
+				t.Cleanup(server.Close)
+
+				probe := NewRemote(server.URL, RemoteClient(server.Client()))
+				report, err := probe.CheckReport(context.Background())
+
+				if tt.err != nil {
+					assert.ErrorIs(t, err, tt.err)
+					assert.Nil(t, report)
+					return
+				}
+
+				if !assert.NotNil(t, report) {
+					return
+				}
+
+				assert.Equal(t, tt.status, report.Status)
+				for _, name := range tt.probes {
+					assert.Contains(t, report.Probes, name)
+				}
+
+				if tt.status == healthcheck.StatusHealthy {
+					assert.NoError(t, err)
+					return
+				}
+
+				var statusErr *healthcheck.StatusError
+				if assert.True(t, errors.As(err, &statusErr)) {
+					assert.Equal(t, tt.status, statusErr.Status)
+				}
+			},
+		)
+	}
+}
+
+func TestRemoteProbe_Nested(t *testing.T) {
+	t.Parallel()
+
+	remote := healthcheck.New(
+		healthcheck.WithSimpleProbe(
+			"database", func(_ context.Context) error {
+				return healthcheck.Degraded("replica lag")
+			},
+		),
+	)
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body, err := json.Marshal(remote.HandleReport(r.Context()))
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(body)
+			},
+		),
+	)
+	t.Cleanup(server.Close)
+
+	hc := healthcheck.New(
+		healthcheck.WithProbe(
+			"remote", NewRemote(server.URL, RemoteClient(server.Client())),
+		),
+	)
+
+	report := hc.HandleReport(context.Background())
+	assert.Equal(t, healthcheck.StatusDegraded, report.Status)
+
+	nested := report.Probes["remote"].Report
+	if assert.NotNil(t, nested) {
+		assert.Equal(
+			t, healthcheck.StatusDegraded, nested.Probes["database"].Status,
+		)
+	}
+}
+
+func TestRemoteProbe_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	hc := healthcheck.New(
+		healthcheck.WithProbe("remote", NewRemote(server.URL)),
+	)
+
+	report := hc.HandleReport(context.Background())
+	assert.Equal(t, healthcheck.StatusUnhealthy, report.Status)
+	assert.Error(t, report.Probes["remote"].Error)
+	assert.Nil(t, report.Probes["remote"].Report)
+}
@@ -0,0 +1,212 @@
+package probes
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nijeti/healthcheck"
+)
+
+// defaultMaxInUseRatio is the default share of the maximum open connections
+// in use from which the connection pool is considered saturated.
+const defaultMaxInUseRatio = 0.9
+
+// SQLProbe is a probe checking a database/sql database.
+// Besides pinging the database, it inspects the statistics of the connection pool
+// and reports healthcheck.StatusDegraded when the pool is saturated.
+// The statistics are reported as the details of the result.
+//
+// When all of the maximum open connections are in use,
+// the database is not pinged, since the ping would only wait
+// for a connection to be released until the check times out,
+// and the saturation is reported right away.
+type SQLProbe struct {
+	db    *sql.DB
+	query string
+
+	maxInUseRatio   float64
+	maxWaits        int64
+	maxWaitDuration time.Duration
+
+	mu   sync.Mutex
+	last sql.DBStats
+}
+
+// SQLOption configures an SQLProbe instance.
+type SQLOption func(p *SQLProbe)
+
+// NewSQL creates a new SQLProbe checking the given database
+// with the provided options.
+// By default, the pool is considered saturated
+// when at least 90% of the maximum open connections are in use,
+// and waiting for connections is not checked.
+func NewSQL(db *sql.DB, opts ...SQLOption) *SQLProbe {
+	p := &SQLProbe{
+		db:              db,
+		maxInUseRatio:   defaultMaxInUseRatio,
+		maxWaits:        -1,
+		maxWaitDuration: -1,
+		last:            db.Stats(),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// SQLQuery makes the probe run the given query instead of pinging the database.
+func SQLQuery(query string) SQLOption {
+	return func(p *SQLProbe) {
+		p.query = query
+	}
+}
+
+// SQLMaxInUseRatio sets the share of the maximum open connections
+// in use from which the pool is considered saturated.
+// The check only applies if the maximum number of open connections is limited.
+// A ratio less than or equal to 0 disables the check.
+func SQLMaxInUseRatio(ratio float64) SQLOption {
+	return func(p *SQLProbe) {
+		p.maxInUseRatio = ratio
+	}
+}
+
+// SQLMaxWaits sets the number of waits for a connection
+// since the previous check above which the pool is considered saturated.
+// A negative count disables the check.
+func SQLMaxWaits(count int64) SQLOption {
+	return func(p *SQLProbe) {
+		p.maxWaits = count
+	}
+}
+
+// SQLMaxWaitDuration sets the total time spent waiting for connections
+// since the previous check above which the pool is considered saturated.
+// A negative duration disables the check.
+func SQLMaxWaitDuration(duration time.Duration) SQLOption {
+	return func(p *SQLProbe) {
+		p.maxWaitDuration = duration
+	}
+}
+
+// Check pings the database or runs the configured query
+// and inspects the statistics of the connection pool.
+func (p *SQLProbe) Check(ctx context.Context) error {
+	_, err := p.CheckDetails(ctx)
+	return err
+}
+
+// CheckDetails checks the database like Check
+// and additionally returns the statistics of the connection pool.
+func (p *SQLProbe) CheckDetails(ctx context.Context) (map[string]any, error) {
+	stats := p.db.Stats()
+	waits, waitDuration := p.waitsSinceLast(stats)
+
+	details := map[string]any{
+		"max_open_connections": stats.MaxOpenConnections,
+		"open_connections":     stats.OpenConnections,
+		"in_use":               stats.InUse,
+		"idle":                 stats.Idle,
+		"wait_count":           stats.WaitCount,
+		"wait_duration":        stats.WaitDuration.String(),
+		"max_idle_closed":      stats.MaxIdleClosed,
+		"max_idle_time_closed": stats.MaxIdleTimeClosed,
+		"max_lifetime_closed":  stats.MaxLifetimeClosed,
+	}
+
+	saturated := p.saturated(stats, waits, waitDuration)
+
+	exhausted := stats.MaxOpenConnections > 0 &&
+		stats.InUse >= stats.MaxOpenConnections
+	if saturated != nil && exhausted {
+		return details, saturated
+	}
+
+	err := p.ping(ctx)
+	if err != nil {
+		return details, err
+	}
+
+	if saturated != nil {
+		return details, saturated
+	}
+
+	return details, nil
+}
+
+// saturated returns the error reporting the connection pool as degraded
+// if the given statistics exceed any of the thresholds and nil otherwise.
+func (p *SQLProbe) saturated(
+	stats sql.DBStats, waits int64, waitDuration time.Duration,
+) error {
+	var reasons []string
+
+	if p.maxInUseRatio > 0 && stats.MaxOpenConnections > 0 {
+		ratio := float64(stats.InUse) / float64(stats.MaxOpenConnections)
+		if ratio >= p.maxInUseRatio {
+			reasons = append(
+				reasons, fmt.Sprintf(
+					"%d of %d connections in use",
+					stats.InUse, stats.MaxOpenConnections,
+				),
+			)
+		}
+	}
+
+	if p.maxWaits >= 0 && waits > p.maxWaits {
+		reasons = append(
+			reasons, fmt.Sprintf("%d waits for connection", waits),
+		)
+	}
+
+	if p.maxWaitDuration >= 0 && waitDuration > p.maxWaitDuration {
+		reasons = append(
+			reasons, fmt.Sprintf("waited %s for connection", waitDuration),
+		)
+	}
+
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	return healthcheck.Degraded(
+		"connection pool is saturated: " + strings.Join(reasons, ", "),
+	)
+}
+
+// waitsSinceLast returns the number of waits for a connection
+// and the time spent waiting since the previous check.
+func (p *SQLProbe) waitsSinceLast(stats sql.DBStats) (int64, time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	waits := stats.WaitCount - p.last.WaitCount
+	waitDuration := stats.WaitDuration - p.last.WaitDuration
+	p.last = stats
+
+	return waits, waitDuration
+}
+
+func (p *SQLProbe) ping(ctx context.Context) error {
+	if p.query == "" {
+		return p.db.PingContext(ctx)
+	}
+
+	rows, err := p.db.QueryContext(ctx, p.query)
+	if err != nil {
+		return err
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,255 @@
+package probes
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nijeti/healthcheck"
+)
+
+// fakeDriver is a database/sql driver whose connections fail
+// pings and queries with the configured error.
+type fakeDriver struct {
+	err atomic.Pointer[error]
+}
+
+func (d *fakeDriver) Open(_ string) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+func (d *fakeDriver) fail(err error) {
+	d.err.Store(&err)
+}
+
+func (d *fakeDriver) error() error {
+	if err := d.err.Load(); err != nil {
+		return *err
+	}
+
+	return nil
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) Prepare(_ string) (driver.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *fakeConn) Ping(_ context.Context) error {
+	return c.driver.error()
+}
+
+func (c *fakeConn) QueryContext(
+	_ context.Context, _ string, _ []driver.NamedValue,
+) (driver.Rows, error) {
+	if err := c.driver.error(); err != nil {
+		return nil, err
+	}
+
+	return fakeRows{}, nil
+}
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string {
+	return nil
+}
+
+func (fakeRows) Close() error {
+	return nil
+}
+
+func (fakeRows) Next(_ []driver.Value) error {
+	return io.EOF
+}
+
+type fakeConnector struct {
+	driver *fakeDriver
+}
+
+func (c fakeConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return c.driver.Open("")
+}
+
+func (c fakeConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+func newFakeDB(t *testing.T) (*sql.DB, *fakeDriver) {
+	t.Helper()
+
+	d := &fakeDriver{}
+	db := sql.OpenDB(fakeConnector{driver: d})
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, d
+}
+
+func TestSQLProbe_Check(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run(
+		"ping", func(t *testing.T) {
+			t.Parallel()
+
+			db, d := newFakeDB(t)
+			probe := NewSQL(db)
+
+			assert.NoError(t, probe.Check(ctx))
+
+			d.fail(errors.New("connection refused"))
+			assert.EqualError(t, probe.Check(ctx), "connection refused")
+		},
+	)
+
+	t.Run(
+		"query", func(t *testing.T) {
+			t.Parallel()
+
+			db, d := newFakeDB(t)
+			probe := NewSQL(db, SQLQuery("SELECT 1"))
+
+			assert.NoError(t, probe.Check(ctx))
+
+			d.fail(errors.New("relation does not exist"))
+			assert.EqualError(t, probe.Check(ctx), "relation does not exist")
+		},
+	)
+
+	t.Run(
+		"in use", func(t *testing.T) {
+			t.Parallel()
+
+			db, _ := newFakeDB(t)
+			db.SetMaxOpenConns(3)
+
+			conn, err := db.Conn(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			probe := NewSQL(db, SQLMaxInUseRatio(0.5))
+			assert.NoError(t, probe.Check(ctx))
+
+			other, err := db.Conn(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			details, err := probe.CheckDetails(ctx)
+			assertDegraded(t, err, "2 of 3 connections in use")
+			assert.Equal(t, 3, details["max_open_connections"])
+			assert.Equal(t, 2, details["in_use"])
+
+			_ = other.Close()
+			_ = conn.Close()
+			assert.NoError(t, probe.Check(ctx))
+		},
+	)
+
+	t.Run(
+		"exhausted", func(t *testing.T) {
+			t.Parallel()
+
+			db, _ := newFakeDB(t)
+			db.SetMaxOpenConns(10)
+
+			conns := make([]*sql.Conn, 10)
+			for i := range conns {
+				conn, err := db.Conn(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(func() { _ = conn.Close() })
+				conns[i] = conn
+			}
+
+			probe := NewSQL(db)
+
+			timedCtx, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			details, err := probe.CheckDetails(timedCtx)
+			assertDegraded(t, err, "10 of 10 connections in use")
+			assert.NoError(t, timedCtx.Err())
+			assert.Equal(t, 10, details["max_open_connections"])
+			assert.Equal(t, 10, details["in_use"])
+
+			_ = conns[0].Close()
+			assertDegraded(t, probe.Check(ctx), "9 of 10 connections in use")
+		},
+	)
+
+	t.Run(
+		"waits", func(t *testing.T) {
+			t.Parallel()
+
+			db, _ := newFakeDB(t)
+			db.SetMaxOpenConns(1)
+
+			probe := NewSQL(
+				db,
+				SQLMaxInUseRatio(0),
+				SQLMaxWaits(0),
+				SQLMaxWaitDuration(time.Hour),
+			)
+
+			conn, err := db.Conn(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			waited := make(chan struct{})
+			go func() {
+				defer close(waited)
+
+				c, err := db.Conn(ctx)
+				if err == nil {
+					_ = c.Close()
+				}
+			}()
+
+			for db.Stats().WaitCount == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			_ = conn.Close()
+			<-waited
+
+			details, err := probe.CheckDetails(ctx)
+			assertDegraded(t, err, "1 waits for connection")
+			assert.Equal(t, int64(1), details["wait_count"])
+
+			assert.NoError(t, probe.Check(ctx))
+		},
+	)
+}
+
+func assertDegraded(t *testing.T, err error, reason string) {
+	t.Helper()
+
+	var statusErr *healthcheck.StatusError
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, healthcheck.StatusDegraded, statusErr.Status)
+		assert.Contains(t, statusErr.Reason, reason)
+	}
+}
@@ -0,0 +1,102 @@
+package probes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+)
+
+// TCPProbe is a probe checking that a TCP connection can be established to an address,
+// optionally exchanging data with the service listening on it.
+type TCPProbe struct {
+	address string
+	send    []byte
+	expect  []byte
+}
+
+// TCPOption configures a TCPProbe instance.
+type TCPOption func(p *TCPProbe)
+
+// NewTCP creates a new TCPProbe dialing the given address
+// with the provided options.
+// The dial and any data exchange are bound to the context of the check.
+func NewTCP(address string, opts ...TCPOption) *TCPProbe {
+	p := &TCPProbe{
+		address: address,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// TCPSend makes the probe send the given data once the connection is established.
+func TCPSend(data []byte) TCPOption {
+	return func(p *TCPProbe) {
+		p.send = data
+	}
+}
+
+// TCPExpect makes the probe expect the service to respond with the given prefix,
+// either to the data sent or as a banner if no data is sent.
+func TCPExpect(prefix []byte) TCPOption {
+	return func(p *TCPProbe) {
+		p.expect = prefix
+	}
+}
+
+// Check dials the address and exchanges the configured data, if any.
+func (p *TCPProbe) Check(ctx context.Context) error {
+	dialer := &net.Dialer{}
+
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if len(p.send) == 0 && len(p.expect) == 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			return err
+		}
+	}
+
+	stop := context.AfterFunc(
+		ctx, func() {
+			_ = conn.Close()
+		},
+	)
+	defer stop()
+
+	if len(p.send) > 0 {
+		_, err = conn.Write(p.send)
+		if err != nil {
+			return fmt.Errorf("failed to send data: %w", err)
+		}
+	}
+
+	if len(p.expect) == 0 {
+		return nil
+	}
+
+	got := make([]byte, len(p.expect))
+	n, err := io.ReadFull(conn, got)
+	if err != nil && n == 0 {
+		return fmt.Errorf("failed to receive response: %w", err)
+	}
+
+	if !bytes.Equal(got[:n], p.expect) {
+		return fmt.Errorf("%w: %q", ErrUnexpectedResponse, got[:n])
+	}
+
+	return nil
+}
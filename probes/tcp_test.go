@@ -0,0 +1,118 @@
+package probes
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTCPProbe_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opts  []TCPOption
+		serve func(conn net.Conn)
+		err   error
+		fails bool
+	}{
+		"connect": {
+			serve: func(_ net.Conn) {},
+		},
+		"banner": {
+			opts: []TCPOption{TCPExpect([]byte("220 "))},
+			serve: func(conn net.Conn) {
+				_, _ = conn.Write([]byte("220 smtp.example.com ESMTP\r\n"))
+			},
+		},
+		"unexpected banner": {
+			opts: []TCPOption{TCPExpect([]byte("220 "))},
+			serve: func(conn net.Conn) {
+				_, _ = conn.Write([]byte("554 no service\r\n"))
+			},
+			err: ErrUnexpectedResponse,
+		},
+		"short banner": {
+			opts: []TCPOption{TCPExpect([]byte("220 "))},
+			serve: func(conn net.Conn) {
+				_, _ = conn.Write([]byte("22"))
+			},
+			err: ErrUnexpectedResponse,
+		},
+		"send and expect": {
+			opts: []TCPOption{
+				TCPSend([]byte("PING\r\n")),
+				TCPExpect([]byte("+PONG")),
+			},
+			serve: func(conn net.Conn) {
+				line, _ := bufio.NewReader(conn).ReadString('\n')
+				if line == "PING\r\n" {
+					_, _ = conn.Write([]byte("+PONG\r\n"))
+				}
+			},
+		},
+		"no response": {
+			opts: []TCPOption{TCPExpect([]byte("220 "))},
+			serve: func(conn net.Conn) {
+				_, _ = conn.Read(make([]byte, 1))
+			},
+			fails: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				ln, err := net.Listen("tcp", "127.0.0.1:0")
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(func() { _ = ln.Close() })
+
+				go func() {
+					conn, err := ln.Accept()
+					if err != nil {
+						return
+					}
+					defer conn.Close()
+
+					tt.serve(conn)
+				}()
+
+				ctx, cancel := context.WithTimeout(
+					context.Background(), 100*time.Millisecond,
+				)
+				defer cancel()
+
+				err = NewTCP(ln.Addr().String(), tt.opts...).Check(ctx)
+
+				switch {
+				case tt.err != nil:
+					assert.ErrorIs(t, err, tt.err)
+				case tt.fails:
+					assert.Error(t, err)
+				default:
+					assert.NoError(t, err)
+				}
+			},
+		)
+	}
+}
+
+func TestTCPProbe_Check_Refused(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := ln.Addr().String()
+	_ = ln.Close()
+
+	assert.Error(t, NewTCP(address).Check(context.Background()))
+}
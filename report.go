@@ -0,0 +1,291 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// Report represents a detailed result of a health check.
+type Report struct {
+	// Status is the aggregated status of all evaluated probes.
+	Status Status
+
+	// Group is the probe group the report is made for.
+	// Empty if the report is made for all probes.
+	Group string
+
+	// Probes contains the result of every evaluated probe by its name.
+	Probes map[string]ProbeResult
+
+	// Override is the override the status has been forced by, if any.
+	Override *Override
+}
+
+func newReport() Report {
+	return Report{
+		Status: StatusUnknown,
+		Probes: map[string]ProbeResult{},
+	}
+}
+
+// clone returns a copy of the report which can be modified independently.
+func (r Report) clone() Report {
+	r.Probes = maps.Clone(r.Probes)
+	return r
+}
+
+// Err returns nil if the status of the report is healthy
+// and a StatusError with the status otherwise,
+// which is the error a probe based on the report returns,
+// so that the status is propagated by the Healthcheck the probe is registered in.
+// An unknown status is propagated as unhealthy,
+// since the nested health check has no known result.
+func (r Report) Err() error {
+	switch r.Status {
+	case StatusHealthy:
+		return nil
+	case StatusDegraded:
+		return Degraded("healthcheck is degraded")
+	default:
+		return NewStatusError(
+			r.Status, fmt.Sprintf("healthcheck is %s", r.Status),
+		)
+	}
+}
+
+// MarshalJSON encodes the report into JSON
+// with human-readable status and probe results.
+func (r Report) MarshalJSON() ([]byte, error) {
+	var override *overrideJSON
+	if r.Override != nil {
+		override = &overrideJSON{
+			Status: &r.Override.Status,
+			Reason: r.Override.Reason,
+		}
+
+		if !r.Override.ExpiresAt.IsZero() {
+			override.ExpiresAt = &r.Override.ExpiresAt
+		}
+	}
+
+	return json.Marshal(
+		reportJSON{
+			Status:   &r.Status,
+			Group:    r.Group,
+			Probes:   r.Probes,
+			Override: override,
+		},
+	)
+}
+
+// UnmarshalJSON decodes the report from JSON as encoded by MarshalJSON,
+// such as the one served by a remote health check.
+// Returns an error wrapping ErrInvalidStatus if the report has no status,
+// so that a JSON document other than a report is not decoded as healthy.
+func (r *Report) UnmarshalJSON(data []byte) error {
+	var decoded reportJSON
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	if decoded.Status == nil ||
+		decoded.Override != nil && decoded.Override.Status == nil {
+		return missingStatus()
+	}
+
+	*r = Report{
+		Status: *decoded.Status,
+		Group:  decoded.Group,
+		Probes: decoded.Probes,
+	}
+
+	if r.Probes == nil {
+		r.Probes = map[string]ProbeResult{}
+	}
+
+	if decoded.Override != nil {
+		r.Override = &Override{
+			Status: *decoded.Override.Status,
+			Reason: decoded.Override.Reason,
+		}
+
+		if decoded.Override.ExpiresAt != nil {
+			r.Override.ExpiresAt = *decoded.Override.ExpiresAt
+		}
+	}
+
+	return nil
+}
+
+type reportJSON struct {
+	Status   *Status                `json:"status"`
+	Group    string                 `json:"group,omitempty"`
+	Probes   map[string]ProbeResult `json:"probes"`
+	Override *overrideJSON          `json:"override,omitempty"`
+}
+
+type overrideJSON struct {
+	Status    *Status    `json:"status"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ProbeResult represents the outcome of a single probe check.
+type ProbeResult struct {
+	// Status is the status the probe has resulted in.
+	Status Status
+
+	// Error is the error returned by the probe, if any.
+	// Probe panics are reported as errors as well.
+	Error error
+
+	// Duration is the time the probe took to complete.
+	Duration time.Duration
+
+	// StartedAt is the time the probe was started at.
+	StartedAt time.Time
+
+	// Critical reports whether the probe is critical.
+	// Failure of a non-critical probe degrades the aggregated status at most.
+	Critical bool
+
+	// TimeoutDegraded is the time after which the probe is considered degraded.
+	TimeoutDegraded time.Duration
+
+	// TimeoutUnhealthy is the time after which the probe is considered unhealthy.
+	TimeoutUnhealthy time.Duration
+
+	// Abandoned reports whether the probe has not returned before its deadline,
+	// which is a grace period after its unhealthy timeout.
+	Abandoned bool
+
+	// ConsecutiveFailures is the number of consecutive failed runs of the probe.
+	ConsecutiveFailures int
+
+	// ConsecutiveSuccesses is the number of consecutive successful runs of the probe.
+	ConsecutiveSuccesses int
+
+	// Details contains the details reported by a DetailedProbe, if any.
+	Details map[string]any
+
+	// Report is the nested report returned by a ReportingProbe, if any.
+	Report *Report
+}
+
+// MarshalJSON encodes the result into JSON
+// with human-readable status, error and durations.
+func (r ProbeResult) MarshalJSON() ([]byte, error) {
+	var errMessage string
+	if r.Error != nil {
+		errMessage = r.Error.Error()
+	}
+
+	return json.Marshal(
+		probeResultJSON{
+			Status:               &r.Status,
+			Error:                errMessage,
+			Duration:             r.Duration.String(),
+			StartedAt:            r.StartedAt,
+			Critical:             r.Critical,
+			TimeoutDegraded:      r.TimeoutDegraded.String(),
+			TimeoutUnhealthy:     r.TimeoutUnhealthy.String(),
+			Abandoned:            r.Abandoned,
+			ConsecutiveFailures:  r.ConsecutiveFailures,
+			ConsecutiveSuccesses: r.ConsecutiveSuccesses,
+			Details:              r.Details,
+			Report:               r.Report,
+		},
+	)
+}
+
+// UnmarshalJSON decodes the result from JSON as encoded by MarshalJSON.
+// The error, if any, is decoded as an error with the same message.
+// Returns an error wrapping ErrInvalidStatus if the result has no status.
+func (r *ProbeResult) UnmarshalJSON(data []byte) error {
+	var decoded probeResultJSON
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	if decoded.Status == nil {
+		return missingStatus()
+	}
+
+	*r = ProbeResult{
+		Status:               *decoded.Status,
+		StartedAt:            decoded.StartedAt,
+		Critical:             decoded.Critical,
+		Abandoned:            decoded.Abandoned,
+		ConsecutiveFailures:  decoded.ConsecutiveFailures,
+		ConsecutiveSuccesses: decoded.ConsecutiveSuccesses,
+		Details:              decoded.Details,
+		Report:               decoded.Report,
+	}
+
+	if decoded.Error != "" {
+		r.Error = errors.New(decoded.Error)
+	}
+
+	r.Duration, err = parseDuration(decoded.Duration)
+	if err != nil {
+		return err
+	}
+
+	r.TimeoutDegraded, err = parseDuration(decoded.TimeoutDegraded)
+	if err != nil {
+		return err
+	}
+
+	r.TimeoutUnhealthy, err = parseDuration(decoded.TimeoutUnhealthy)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// missingStatus returns an error wrapping ErrInvalidStatus
+// for a decoded JSON object without a status.
+func missingStatus() error {
+	return fmt.Errorf("%w: status is missing", ErrInvalidStatus)
+}
+
+// parseDuration parses a duration encoded by time.Duration.String,
+// treating an empty string as zero.
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(s)
+}
+
+type probeResultJSON struct {
+	Status               *Status        `json:"status"`
+	Error                string         `json:"error,omitempty"`
+	Duration             string         `json:"duration"`
+	StartedAt            time.Time      `json:"started_at"`
+	Critical             bool           `json:"critical"`
+	TimeoutDegraded      string         `json:"timeout_degraded"`
+	TimeoutUnhealthy     string         `json:"timeout_unhealthy"`
+	Abandoned            bool           `json:"abandoned"`
+	ConsecutiveFailures  int            `json:"consecutive_failures"`
+	ConsecutiveSuccesses int            `json:"consecutive_successes"`
+	Details              map[string]any `json:"details,omitempty"`
+	Report               *Report        `json:"report,omitempty"`
+}
+
+// Impact returns the status the result contributes to the aggregated status.
+// Failure of a non-critical probe is capped at StatusDegraded.
+func (r ProbeResult) Impact() Status {
+	if !r.Critical && r.Status.severity() > StatusDegraded.severity() {
+		return StatusDegraded
+	}
+
+	return r.Status
+}
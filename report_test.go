@@ -0,0 +1,209 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbeResult_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	result := ProbeResult{
+		Status:               StatusUnhealthy,
+		Error:                errors.New("probe error"),
+		Duration:             1500 * time.Millisecond,
+		StartedAt:            time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Critical:             true,
+		TimeoutDegraded:      time.Second,
+		TimeoutUnhealthy:     10 * time.Second,
+		ConsecutiveFailures:  2,
+		ConsecutiveSuccesses: 0,
+		Details:              map[string]any{"in_use": 10},
+		Report: &Report{
+			Status: StatusUnhealthy,
+			Probes: map[string]ProbeResult{},
+		},
+	}
+
+	data, err := json.Marshal(result)
+	assert.NoError(t, err)
+	assert.JSONEq(
+		t, `{
+			"status": "unhealthy",
+			"error": "probe error",
+			"duration": "1.5s",
+			"started_at": "2024-01-02T03:04:05Z",
+			"critical": true,
+			"timeout_degraded": "1s",
+			"timeout_unhealthy": "10s",
+			"abandoned": false,
+			"consecutive_failures": 2,
+			"consecutive_successes": 0,
+			"details": {"in_use": 10},
+			"report": {"status": "unhealthy", "probes": {}}
+		}`, string(data),
+	)
+
+	data, err = json.Marshal(ProbeResult{Status: StatusHealthy})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), `"error"`)
+	assert.NotContains(t, string(data), `"details"`)
+	assert.NotContains(t, string(data), `"report"`)
+}
+
+func TestReport_Err(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Report{Status: StatusHealthy}.Err())
+
+	for _, status := range []Status{
+		StatusDegraded, StatusUnhealthy, StatusStarting, StatusUnknown,
+	} {
+		var statusErr *StatusError
+		if assert.ErrorAs(t, Report{Status: status}.Err(), &statusErr) {
+			assert.Equal(t, status, statusErr.Status)
+		}
+	}
+}
+
+func TestReport_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	report := Report{
+		Status: StatusUnhealthy,
+		Group:  GroupReadiness,
+		Probes: map[string]ProbeResult{
+			"probe": {
+				Status:           StatusHealthy,
+				Duration:         time.Millisecond,
+				StartedAt:        expiresAt,
+				Critical:         true,
+				TimeoutDegraded:  time.Second,
+				TimeoutUnhealthy: 10 * time.Second,
+			},
+		},
+		Override: &Override{
+			Status:    StatusUnhealthy,
+			Reason:    "draining",
+			ExpiresAt: expiresAt,
+		},
+	}
+
+	data, err := json.Marshal(report)
+	assert.NoError(t, err)
+	assert.JSONEq(
+		t, `{
+			"status": "unhealthy",
+			"group": "readiness",
+			"probes": {
+				"probe": {
+					"status": "healthy",
+					"duration": "1ms",
+					"started_at": "2024-01-02T03:04:05Z",
+					"critical": true,
+					"timeout_degraded": "1s",
+					"timeout_unhealthy": "10s",
+					"abandoned": false,
+					"consecutive_failures": 0,
+					"consecutive_successes": 0
+				}
+			},
+			"override": {
+				"status": "unhealthy",
+				"reason": "draining",
+				"expires_at": "2024-01-02T03:04:05Z"
+			}
+		}`, string(data),
+	)
+
+	data, err = json.Marshal(newReport())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status": "unknown", "probes": {}}`, string(data))
+}
+
+func TestReport_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	report := Report{
+		Status: StatusDegraded,
+		Group:  GroupReadiness,
+		Probes: map[string]ProbeResult{
+			"database": {
+				Status:               StatusDegraded,
+				Error:                Degraded("replica lag"),
+				Duration:             1500 * time.Millisecond,
+				StartedAt:            startedAt,
+				Critical:             true,
+				TimeoutDegraded:      time.Second,
+				TimeoutUnhealthy:     10 * time.Second,
+				ConsecutiveSuccesses: 3,
+				Details:              map[string]any{"lag": "40s"},
+			},
+			"payments": {
+				Status:           StatusHealthy,
+				StartedAt:        startedAt,
+				TimeoutDegraded:  time.Second,
+				TimeoutUnhealthy: 10 * time.Second,
+				Report: &Report{
+					Status: StatusHealthy,
+					Probes: map[string]ProbeResult{},
+				},
+			},
+		},
+		Override: &Override{
+			Status:    StatusDegraded,
+			Reason:    "maintenance",
+			ExpiresAt: startedAt,
+		},
+	}
+
+	data, err := json.Marshal(report)
+	assert.NoError(t, err)
+
+	var decoded Report
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+
+	database := decoded.Probes["database"]
+	assert.EqualError(t, database.Error, "degraded: replica lag")
+	database.Error = report.Probes["database"].Error
+	decoded.Probes["database"] = database
+
+	assert.Equal(t, report, decoded)
+
+	assert.NoError(
+		t, json.Unmarshal([]byte(`{"status": "healthy"}`), &decoded),
+	)
+	assert.Equal(
+		t, Report{Status: StatusHealthy, Probes: map[string]ProbeResult{}},
+		decoded,
+	)
+
+	assert.ErrorIs(
+		t, json.Unmarshal([]byte(`{"status": "fine"}`), &decoded),
+		ErrInvalidStatus,
+	)
+
+	assert.Error(
+		t, json.Unmarshal(
+			[]byte(`{"status": "healthy", "probes": {"p": {"duration": "soon"}}}`),
+			&decoded,
+		),
+	)
+	for _, data := range []string{
+		`{"probe": []}`,
+		`{"status": "healthy", "probes": {"p": {"critical": true}}}`,
+		`{"status": "healthy", "override": {"reason": "maintenance"}}`,
+	} {
+		assert.ErrorIs(
+			t, json.Unmarshal([]byte(data), &decoded), ErrInvalidStatus,
+		)
+	}
+}
@@ -0,0 +1,58 @@
+package fasthttp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidOption is returned by NewE when an option is invalid.
+var ErrInvalidOption = errors.New("healthcheck server option is invalid")
+
+// optionError is an error with its own message wrapping ErrInvalidOption.
+type optionError struct {
+	message string
+}
+
+// Error returns the message of the error.
+func (e *optionError) Error() string {
+	return e.message
+}
+
+// Unwrap returns ErrInvalidOption.
+func (e *optionError) Unwrap() error {
+	return ErrInvalidOption
+}
+
+// invalidOption returns an error with the given message wrapping ErrInvalidOption.
+func invalidOption(message string) error {
+	return &optionError{message: message}
+}
+
+// routeAlreadyRegistered returns an error wrapping ErrInvalidOption
+// for the route registered more than once.
+func routeAlreadyRegistered(route string) error {
+	return invalidOption(
+		fmt.Sprintf("healthcheck server route '%s' already registered", route),
+	)
+}
+
+// optionErrors collects the errors of failed options.
+// Unless collecting, a failure panics with the error message instead.
+type optionErrors struct {
+	collecting bool
+	errs       []error
+}
+
+// fail records the error or panics with its message if not collecting.
+func (f *optionErrors) fail(err error) {
+	if !f.collecting {
+		panic(err.Error())
+	}
+
+	f.errs = append(f.errs, err)
+}
+
+// err returns the collected errors joined into one, or nil if there are none.
+func (f *optionErrors) err() error {
+	return errors.Join(f.errs...)
+}
@@ -1,8 +1,13 @@
 package fasthttp
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net"
+	"time"
 
 	"github.com/valyala/fasthttp"
 
@@ -22,24 +27,68 @@ type Server struct {
 	logger            *slog.Logger
 	listen            func() (net.Listener, error)
 	route             string
+	groupRoutes       map[string]string
+	reportRoute       string
+	historyRoute      string
 	statusAdapterFunc func(status healthcheck.Status) (int, string)
+	routeAdapters     map[string]func(status healthcheck.Status) (int, string)
+	drainGroup        string
+	drainGroupSet     bool
+	optionErrs        optionErrors
 }
 
 // New creates a new Server instance
 // operating provided Healthcheck instance and with the provided options.
+// Panics if any option is invalid or any route is registered more than once.
 func New(hc *healthcheck.Healthcheck, opts ...Option) *Server {
+	s, _ := newServer(hc, opts, false)
+	return s
+}
+
+// NewE creates a new Server instance
+// operating provided Healthcheck instance and with the provided options.
+// Unlike New, it does not panic on invalid options
+// and returns the errors of all of them joined instead.
+// Each of the errors wraps ErrInvalidOption.
+func NewE(hc *healthcheck.Healthcheck, opts ...Option) (*Server, error) {
+	s, err := newServer(hc, opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func newServer(
+	hc *healthcheck.Healthcheck, opts []Option, collect bool,
+) (*Server, error) {
 	s := &Server{
 		hc:                hc,
 		logger:            slog.Default(),
 		listen:            listen(defaultAddr),
 		route:             defaultRoute,
+		groupRoutes:       map[string]string{},
 		statusAdapterFunc: defaultAdapter,
+		routeAdapters:     map[string]func(healthcheck.Status) (int, string){},
+		optionErrs:        optionErrors{collecting: collect},
 	}
 
 	for _, opt := range opts {
 		opt(s)
 	}
 
+	s.checkRoutes()
+
+	if !s.drainGroupSet {
+		s.drainGroup = s.defaultDrainGroup()
+	}
+
+	err := s.optionErrs.err()
+	s.optionErrs = optionErrors{}
+	if err != nil {
+		return nil, err
+	}
+
 	s.server = &fasthttp.Server{
 		Handler:                      s.handle,
 		ErrorHandler:                 s.handleError,
@@ -48,7 +97,7 @@ func New(hc *healthcheck.Healthcheck, opts ...Option) *Server {
 		NoDefaultServerHeader:        true,
 	}
 
-	return s
+	return s, nil
 }
 
 // Start launches the HTTP server in a separate goroutine to handle health check requests.
@@ -77,8 +126,38 @@ func (s *Server) Stop() {
 	}
 }
 
+// Drain makes the drain group of the Healthcheck report unhealthy,
+// keeps serving health check requests for the given period
+// and then gracefully shuts down the HTTP server.
+// The drain group is the one set by WithDrainGroup,
+// or the readiness group if the server has a route for it,
+// or all probes otherwise.
+// If ctx is done before the period is over, the server is shut down immediately
+// and the context error is returned.
+func (s *Server) Drain(ctx context.Context, period time.Duration) error {
+	err := s.hc.DrainGroup(ctx, s.drainGroup, period)
+	if err != nil {
+		return errors.Join(err, s.server.ShutdownWithContext(ctx))
+	}
+
+	return s.server.ShutdownWithContext(ctx)
+}
+
 func (s *Server) handle(ctx *fasthttp.RequestCtx) {
-	if string(ctx.Path()) != "/health" {
+	path := string(ctx.Path())
+
+	if s.reportRoute != "" && path == s.reportRoute {
+		s.handleReport(ctx)
+		return
+	}
+
+	if s.historyRoute != "" && path == s.historyRoute {
+		s.handleHistory(ctx)
+		return
+	}
+
+	group, ok := s.groupRoutes[path]
+	if !ok && path != s.route {
 		ctx.Error("not found", fasthttp.StatusNotFound)
 		return
 	}
@@ -88,17 +167,68 @@ func (s *Server) handle(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	status := s.hc.Handle(ctx)
-	code, message := s.statusAdapterFunc(status)
+	status := s.status(requestContext(ctx), group)
+	code, message := s.adapter(path, group)(status)
 
 	ctx.SetStatusCode(code)
 	ctx.SetBodyString(message)
 }
 
+func (s *Server) handleReport(ctx *fasthttp.RequestCtx) {
+	if !ctx.IsGet() {
+		ctx.Error("method not allowed", fasthttp.StatusMethodNotAllowed)
+		return
+	}
+
+	group := string(ctx.QueryArgs().Peek("group"))
+
+	report := s.report(requestContext(ctx), group)
+	code, _ := s.adapter(s.reportRoute, group)(report.Status)
+
+	s.writeJSON(ctx, code, report)
+}
+
+func (s *Server) handleHistory(ctx *fasthttp.RequestCtx) {
+	if !ctx.IsGet() {
+		ctx.Error("method not allowed", fasthttp.StatusMethodNotAllowed)
+		return
+	}
+
+	s.writeJSON(ctx, fasthttp.StatusOK, s.hc.Histories())
+}
+
+func (s *Server) writeJSON(ctx *fasthttp.RequestCtx, code int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to encode response", "error", err)
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetStatusCode(code)
+	ctx.SetContentType("application/json")
+	ctx.SetBody(body)
+}
+
 func (s *Server) handleError(ctx *fasthttp.RequestCtx, err error) {
 	s.logger.ErrorContext(ctx, "healthcheck server error", "error", err)
 }
 
+// requestContext returns a context carrying copies of the user values of the request.
+// The request context itself must not be passed to the probes,
+// since an abandoned probe may outlive the handler,
+// after which fasthttp reuses the request context for other requests.
+func requestContext(ctx *fasthttp.RequestCtx) context.Context {
+	c := context.Background()
+	ctx.VisitUserValuesAll(
+		func(key, value any) {
+			c = context.WithValue(c, key, value)
+		},
+	)
+
+	return c
+}
+
 func listen(addr string) func() (net.Listener, error) {
 	return func() (net.Listener, error) {
 		return net.Listen("tcp", addr)
@@ -116,7 +246,103 @@ func defaultAdapter(status healthcheck.Status) (int, string) {
 		code = fasthttp.StatusOK
 	case healthcheck.StatusUnhealthy:
 		code = fasthttp.StatusServiceUnavailable
+	case healthcheck.StatusStarting:
+		code = fasthttp.StatusServiceUnavailable
 	}
 
 	return code, message
 }
+
+// defaultDrainGroup returns the readiness group if the server has a route for it
+// and an empty group standing for all probes otherwise.
+func (s *Server) defaultDrainGroup() string {
+	for _, group := range s.groupRoutes {
+		if group == healthcheck.GroupReadiness {
+			return group
+		}
+	}
+
+	return ""
+}
+
+// adapter returns the status adapter of the route serving the given group.
+// Unless the route has its own adapter, liveness is served by livenessAdapter.
+func (s *Server) adapter(
+	route, group string,
+) func(status healthcheck.Status) (int, string) {
+	if adapter, ok := s.routeAdapters[route]; ok {
+		return adapter
+	}
+
+	if group == healthcheck.GroupLiveness {
+		return s.livenessAdapter
+	}
+
+	return s.statusAdapterFunc
+}
+
+// livenessAdapter converts healthcheck status like the status adapter,
+// except that starting is reported with OK code,
+// since a starting application is alive and must not be restarted.
+func (s *Server) livenessAdapter(status healthcheck.Status) (int, string) {
+	code, message := s.statusAdapterFunc(status)
+	if status == healthcheck.StatusStarting {
+		code = fasthttp.StatusOK
+	}
+
+	return code, message
+}
+
+// checkRoutes fails if any route is registered more than once
+// or a status adapter is set for a route which is not registered.
+func (s *Server) checkRoutes() {
+	routes := []string{s.route}
+	for route := range s.groupRoutes {
+		routes = append(routes, route)
+	}
+	if s.reportRoute != "" {
+		routes = append(routes, s.reportRoute)
+	}
+	if s.historyRoute != "" {
+		routes = append(routes, s.historyRoute)
+	}
+
+	seen := map[string]struct{}{}
+	for _, route := range routes {
+		if _, ok := seen[route]; ok {
+			s.optionErrs.fail(routeAlreadyRegistered(route))
+			continue
+		}
+
+		seen[route] = struct{}{}
+	}
+
+	for route := range s.routeAdapters {
+		if _, ok := seen[route]; !ok {
+			s.optionErrs.fail(
+				invalidOption(
+					fmt.Sprintf(
+						"healthcheck server status adapter route '%s' is not registered",
+						route,
+					),
+				),
+			)
+		}
+	}
+}
+
+func (s *Server) status(ctx context.Context, group string) healthcheck.Status {
+	if group == "" {
+		return s.hc.Handle(ctx)
+	}
+
+	return s.hc.HandleGroup(ctx, group)
+}
+
+func (s *Server) report(ctx context.Context, group string) healthcheck.Report {
+	if group == "" {
+		return s.hc.HandleReport(ctx)
+	}
+
+	return s.hc.HandleGroupReport(ctx, group)
+}
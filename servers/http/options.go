@@ -9,13 +9,22 @@ import (
 )
 
 // Option configures a Healthcheck server instance.
+// An option fails on invalid input,
+// which makes New panic and NewE return an error.
 type Option func(server *Server)
 
+// failed returns an Option which fails with the given error.
+func failed(err error) Option {
+	return func(s *Server) {
+		s.optionErrs.fail(err)
+	}
+}
+
 // WithLogger sets the logger for the Healthcheck server.
-// Panics if logger is nil.
+// Fails if logger is nil.
 func WithLogger(logger *slog.Logger) Option {
 	if logger == nil {
-		panic("healthcheck server logger cannot be nil")
+		return failed(invalidOption("healthcheck server logger cannot be nil"))
 	}
 
 	return func(s *Server) {
@@ -24,10 +33,12 @@ func WithLogger(logger *slog.Logger) Option {
 }
 
 // WithListener sets a custom net.Listener for the Healthcheck server.
-// Panics if listener is empty
+// Fails if listener is nil.
 func WithListener(listener net.Listener) Option {
 	if listener == nil {
-		panic("healthcheck server listener cannot be nil")
+		return failed(
+			invalidOption("healthcheck server listener cannot be nil"),
+		)
 	}
 
 	return func(s *Server) {
@@ -38,10 +49,12 @@ func WithListener(listener net.Listener) Option {
 }
 
 // WithAddress sets the address for the Healthcheck server.
-// Panics if address is empty.
+// Fails if address is empty.
 func WithAddress(address string) Option {
 	if address == "" {
-		panic("healthcheck server address cannot be empty")
+		return failed(
+			invalidOption("healthcheck server address cannot be empty"),
+		)
 	}
 
 	return func(server *Server) {
@@ -50,10 +63,10 @@ func WithAddress(address string) Option {
 }
 
 // WithRoute sets the route path for the Healthcheck server.
-// Panics if route is of invalid format.
+// Fails if route is of invalid format.
 func WithRoute(route string) Option {
 	if !strings.HasPrefix(route, "/") {
-		panic("healthcheck server route is invalid")
+		return failed(invalidOption("healthcheck server route is invalid"))
 	}
 
 	return func(server *Server) {
@@ -61,16 +74,108 @@ func WithRoute(route string) Option {
 	}
 }
 
+// WithGroupRoute exposes the given group of probes on a separate route
+// in addition to the main route serving all probes.
+// Routes of the liveness group report the starting status with OK code,
+// so that liveness checks do not restart the application while it is starting.
+// Fails if route is of invalid format, group is empty
+// or the route is already registered.
+func WithGroupRoute(route string, group string) Option {
+	if !strings.HasPrefix(route, "/") {
+		return failed(invalidOption("healthcheck server route is invalid"))
+	}
+
+	if group == "" {
+		return failed(
+			invalidOption("healthcheck server route group cannot be empty"),
+		)
+	}
+
+	return func(server *Server) {
+		if _, ok := server.groupRoutes[route]; ok {
+			server.optionErrs.fail(routeAlreadyRegistered(route))
+			return
+		}
+
+		server.groupRoutes[route] = group
+	}
+}
+
+// WithReportRoute exposes the detailed report of the Healthcheck as JSON on the given route.
+// The response code is produced by the status adapter.
+// The optional "group" query parameter limits the report to the given probe group.
+// Fails if route is of invalid format.
+func WithReportRoute(route string) Option {
+	if !strings.HasPrefix(route, "/") {
+		return failed(invalidOption("healthcheck server route is invalid"))
+	}
+
+	return func(server *Server) {
+		server.reportRoute = route
+	}
+}
+
+// WithHistoryRoute exposes the retained probe results as JSON on the given route.
+// History must be enabled on the Healthcheck instance
+// via healthcheck.WithHistorySize for the route to return any results.
+// Fails if route is of invalid format.
+func WithHistoryRoute(route string) Option {
+	if !strings.HasPrefix(route, "/") {
+		return failed(invalidOption("healthcheck server route is invalid"))
+	}
+
+	return func(server *Server) {
+		server.historyRoute = route
+	}
+}
+
 // WithStatusAdapter sets a custom adapter function for converting healthcheck status.
-// Panics if adapterFunc is nil.
+// Fails if adapterFunc is nil.
 func WithStatusAdapter(
 	adapterFunc func(status healthcheck.Status) (int, string),
 ) Option {
 	if adapterFunc == nil {
-		panic("healthcheck server status adapter func cannot be nil")
+		return failed(
+			invalidOption("healthcheck server status adapter func cannot be nil"),
+		)
 	}
 
 	return func(server *Server) {
 		server.statusAdapterFunc = adapterFunc
 	}
 }
+
+// WithRouteStatusAdapter sets a custom adapter function
+// for converting healthcheck status served on the given route only,
+// overriding the one set by WithStatusAdapter.
+// By default, liveness group routes report starting with OK code
+// and otherwise use the adapter set by WithStatusAdapter.
+// Fails if route is of invalid format, adapterFunc is nil
+// or the route is not registered with the server.
+func WithRouteStatusAdapter(
+	route string, adapterFunc func(status healthcheck.Status) (int, string),
+) Option {
+	if !strings.HasPrefix(route, "/") {
+		return failed(invalidOption("healthcheck server route is invalid"))
+	}
+
+	if adapterFunc == nil {
+		return failed(
+			invalidOption("healthcheck server status adapter func cannot be nil"),
+		)
+	}
+
+	return func(server *Server) {
+		server.routeAdapters[route] = adapterFunc
+	}
+}
+
+// WithDrainGroup sets the probe group Drain makes report unhealthy,
+// such as the group served on the readiness route of the server.
+// An empty group makes all probes report unhealthy.
+func WithDrainGroup(group string) Option {
+	return func(server *Server) {
+		server.drainGroup = group
+		server.drainGroupSet = true
+	}
+}
@@ -92,6 +92,76 @@ func TestWithRoute(t *testing.T) {
 	assert.Equal(t, route, s.route)
 }
 
+func TestWithGroupRoute(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{
+		groupRoutes: map[string]string{},
+	}
+
+	assert.PanicsWithValue(
+		t, "healthcheck server route is invalid",
+		func() {
+			WithGroupRoute("livez", healthcheck.GroupLiveness)(s)
+		},
+	)
+	assert.PanicsWithValue(
+		t, "healthcheck server route group cannot be empty",
+		func() {
+			WithGroupRoute("/livez", "")(s)
+		},
+	)
+
+	WithGroupRoute("/livez", healthcheck.GroupLiveness)(s)
+	WithGroupRoute("/readyz", healthcheck.GroupReadiness)(s)
+	assert.PanicsWithValue(
+		t, "healthcheck server route '/livez' already registered",
+		func() {
+			WithGroupRoute("/livez", healthcheck.GroupStartup)(s)
+		},
+	)
+	assert.Equal(
+		t, map[string]string{
+			"/livez":  healthcheck.GroupLiveness,
+			"/readyz": healthcheck.GroupReadiness,
+		}, s.groupRoutes,
+	)
+}
+
+func TestWithReportRoute(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck server route is invalid",
+		func() {
+			WithReportRoute("report")(s)
+		},
+	)
+
+	route := "/health/report"
+	WithReportRoute(route)(s)
+	assert.Equal(t, route, s.reportRoute)
+}
+
+func TestWithHistoryRoute(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{}
+
+	assert.PanicsWithValue(
+		t, "healthcheck server route is invalid",
+		func() {
+			WithHistoryRoute("history")(s)
+		},
+	)
+
+	route := "/health/history"
+	WithHistoryRoute(route)(s)
+	assert.Equal(t, route, s.historyRoute)
+}
+
 func TestWithStatusAdapter(t *testing.T) {
 	t.Parallel()
 
@@ -114,3 +184,44 @@ func TestWithStatusAdapter(t *testing.T) {
 	assert.Equal(t, wantCode, gotCode)
 	assert.Equal(t, wantMsg, gotMsg)
 }
+
+func TestWithRouteStatusAdapter(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{
+		routeAdapters: map[string]func(healthcheck.Status) (int, string){},
+	}
+
+	adapter := func(status healthcheck.Status) (int, string) {
+		return 200, "OK"
+	}
+
+	assert.PanicsWithValue(
+		t, "healthcheck server route is invalid",
+		func() {
+			WithRouteStatusAdapter("livez", adapter)(s)
+		},
+	)
+	assert.PanicsWithValue(
+		t, "healthcheck server status adapter func cannot be nil",
+		func() {
+			WithRouteStatusAdapter("/livez", nil)(s)
+		},
+	)
+
+	WithRouteStatusAdapter("/livez", adapter)(s)
+
+	gotCode, gotMsg := s.routeAdapters["/livez"](0)
+	assert.Equal(t, 200, gotCode)
+	assert.Equal(t, "OK", gotMsg)
+}
+
+func TestWithDrainGroup(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{}
+
+	WithDrainGroup("ready")(s)
+	assert.Equal(t, "ready", s.drainGroup)
+	assert.True(t, s.drainGroupSet)
+}
@@ -1,9 +1,14 @@
 package http
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/nijeti/healthcheck"
 )
@@ -21,31 +26,84 @@ type Server struct {
 	logger            *slog.Logger
 	listen            func() (net.Listener, error)
 	route             string
+	groupRoutes       map[string]string
+	reportRoute       string
+	historyRoute      string
 	statusAdapterFunc func(status healthcheck.Status) (int, string)
+	routeAdapters     map[string]func(status healthcheck.Status) (int, string)
+	drainGroup        string
+	drainGroupSet     bool
+	optionErrs        optionErrors
 }
 
 // New creates a new Server instance
 // operating provided Healthcheck instance and with the provided options.
+// Panics if any option is invalid or any route is registered more than once.
 func New(hc *healthcheck.Healthcheck, opts ...Option) *Server {
+	s, _ := newServer(hc, opts, false)
+	return s
+}
+
+// NewE creates a new Server instance
+// operating provided Healthcheck instance and with the provided options.
+// Unlike New, it does not panic on invalid options
+// and returns the errors of all of them joined instead.
+// Each of the errors wraps ErrInvalidOption.
+func NewE(hc *healthcheck.Healthcheck, opts ...Option) (*Server, error) {
+	s, err := newServer(hc, opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func newServer(
+	hc *healthcheck.Healthcheck, opts []Option, collect bool,
+) (*Server, error) {
 	s := &Server{
 		hc:                hc,
 		logger:            slog.Default(),
 		listen:            listen(defaultAddr),
 		route:             defaultRoute,
+		groupRoutes:       map[string]string{},
 		statusAdapterFunc: defaultAdapter,
+		routeAdapters:     map[string]func(healthcheck.Status) (int, string){},
+		optionErrs:        optionErrors{collecting: collect},
 	}
 
 	for _, opt := range opts {
 		opt(s)
 	}
 
+	s.checkRoutes()
+
+	if !s.drainGroupSet {
+		s.drainGroup = s.defaultDrainGroup()
+	}
+
+	err := s.optionErrs.err()
+	s.optionErrs = optionErrors{}
+	if err != nil {
+		return nil, err
+	}
+
 	mux := http.NewServeMux()
-	mux.HandleFunc(s.route, s.handle)
+	mux.HandleFunc(s.route, s.handle(s.route, ""))
+	for route, group := range s.groupRoutes {
+		mux.HandleFunc(route, s.handle(route, group))
+	}
+	if s.reportRoute != "" {
+		mux.HandleFunc(s.reportRoute, s.handleReport)
+	}
+	if s.historyRoute != "" {
+		mux.HandleFunc(s.historyRoute, s.handleHistory)
+	}
 	s.server = &http.Server{
 		Handler: mux,
 	}
 
-	return s
+	return s, nil
 }
 
 // Start launches the HTTP server in a separate goroutine to handle health check requests.
@@ -60,7 +118,7 @@ func (s *Server) Start() {
 		}
 
 		err = s.server.Serve(ln)
-		if err != nil {
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			s.logger.Error("healthcheck server error", "error", err)
 		}
 	}()
@@ -74,31 +132,97 @@ func (s *Server) Stop() {
 	}
 }
 
-func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+// Drain makes the drain group of the Healthcheck report unhealthy,
+// keeps serving health check requests for the given period
+// and then gracefully shuts down the HTTP server.
+// The drain group is the one set by WithDrainGroup,
+// or the readiness group if the server has a route for it,
+// or all probes otherwise.
+// If ctx is done before the period is over, the server is shut down immediately
+// and the context error is returned.
+func (s *Server) Drain(ctx context.Context, period time.Duration) error {
+	err := s.hc.DrainGroup(ctx, s.drainGroup, period)
+	if err != nil {
+		return errors.Join(err, s.server.Close())
+	}
 
-		_, err := w.Write([]byte("method not allowed"))
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Server) handle(route, group string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			s.methodNotAllowed(w)
+			return
+		}
+
+		ctx := r.Context()
+
+		status := s.status(ctx, group)
+		code, message := s.adapter(route, group)(status)
+
+		w.WriteHeader(code)
+
+		_, err := w.Write([]byte(message))
 		if err != nil {
-			s.logger.Error("failed to write response", "error", err)
+			s.logger.ErrorContext(ctx, "failed to write response", "error", err)
 		}
+	}
+}
 
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.methodNotAllowed(w)
 		return
 	}
 
 	ctx := r.Context()
 
-	status := s.hc.Handle(ctx)
-	code, message := s.statusAdapterFunc(status)
+	group := r.URL.Query().Get("group")
+
+	report := s.report(ctx, group)
+	code, _ := s.adapter(s.reportRoute, group)(report.Status)
 
+	s.writeJSON(ctx, w, code, report)
+}
+
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.methodNotAllowed(w)
+		return
+	}
+
+	s.writeJSON(r.Context(), w, http.StatusOK, s.hc.Histories())
+}
+
+func (s *Server) writeJSON(
+	ctx context.Context, w http.ResponseWriter, code int, v any,
+) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to encode response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 
-	_, err := w.Write([]byte(message))
+	_, err = w.Write(body)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "failed to write response", "error", err)
 	}
 }
 
+func (s *Server) methodNotAllowed(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusMethodNotAllowed)
+
+	_, err := w.Write([]byte("method not allowed"))
+	if err != nil {
+		s.logger.Error("failed to write response", "error", err)
+	}
+}
+
 func listen(addr string) func() (net.Listener, error) {
 	return func() (net.Listener, error) {
 		return net.Listen("tcp", addr)
@@ -116,7 +240,103 @@ func defaultAdapter(status healthcheck.Status) (int, string) {
 		code = http.StatusOK
 	case healthcheck.StatusUnhealthy:
 		code = http.StatusServiceUnavailable
+	case healthcheck.StatusStarting:
+		code = http.StatusServiceUnavailable
+	}
+
+	return code, message
+}
+
+// defaultDrainGroup returns the readiness group if the server has a route for it
+// and an empty group standing for all probes otherwise.
+func (s *Server) defaultDrainGroup() string {
+	for _, group := range s.groupRoutes {
+		if group == healthcheck.GroupReadiness {
+			return group
+		}
+	}
+
+	return ""
+}
+
+// adapter returns the status adapter of the route serving the given group.
+// Unless the route has its own adapter, liveness is served by livenessAdapter.
+func (s *Server) adapter(
+	route, group string,
+) func(status healthcheck.Status) (int, string) {
+	if adapter, ok := s.routeAdapters[route]; ok {
+		return adapter
+	}
+
+	if group == healthcheck.GroupLiveness {
+		return s.livenessAdapter
+	}
+
+	return s.statusAdapterFunc
+}
+
+// livenessAdapter converts healthcheck status like the status adapter,
+// except that starting is reported with OK code,
+// since a starting application is alive and must not be restarted.
+func (s *Server) livenessAdapter(status healthcheck.Status) (int, string) {
+	code, message := s.statusAdapterFunc(status)
+	if status == healthcheck.StatusStarting {
+		code = http.StatusOK
 	}
 
 	return code, message
 }
+
+// checkRoutes fails if any route is registered more than once
+// or a status adapter is set for a route which is not registered.
+func (s *Server) checkRoutes() {
+	routes := []string{s.route}
+	for route := range s.groupRoutes {
+		routes = append(routes, route)
+	}
+	if s.reportRoute != "" {
+		routes = append(routes, s.reportRoute)
+	}
+	if s.historyRoute != "" {
+		routes = append(routes, s.historyRoute)
+	}
+
+	seen := map[string]struct{}{}
+	for _, route := range routes {
+		if _, ok := seen[route]; ok {
+			s.optionErrs.fail(routeAlreadyRegistered(route))
+			continue
+		}
+
+		seen[route] = struct{}{}
+	}
+
+	for route := range s.routeAdapters {
+		if _, ok := seen[route]; !ok {
+			s.optionErrs.fail(
+				invalidOption(
+					fmt.Sprintf(
+						"healthcheck server status adapter route '%s' is not registered",
+						route,
+					),
+				),
+			)
+		}
+	}
+}
+
+func (s *Server) status(ctx context.Context, group string) healthcheck.Status {
+	if group == "" {
+		return s.hc.Handle(ctx)
+	}
+
+	return s.hc.HandleGroup(ctx, group)
+}
+
+func (s *Server) report(ctx context.Context, group string) healthcheck.Report {
+	if group == "" {
+		return s.hc.HandleReport(ctx)
+	}
+
+	return s.hc.HandleGroupReport(ctx, group)
+}
@@ -0,0 +1,482 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nijeti/healthcheck"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	hc := healthcheck.New()
+
+	assert.PanicsWithValue(
+		t, "healthcheck server route '/health' already registered",
+		func() {
+			New(hc, WithReportRoute("/health"))
+		},
+	)
+
+	assert.NotPanics(
+		t, func() {
+			New(hc)
+		},
+	)
+}
+
+func TestNewE(t *testing.T) {
+	t.Parallel()
+
+	hc := healthcheck.New()
+
+	tests := map[string]struct {
+		opts []Option
+		msgs []string
+	}{
+		"valid": {
+			opts: []Option{
+				WithRoute("/live"),
+				WithReportRoute("/report"),
+			},
+		},
+		"invalid option": {
+			opts: []Option{WithAddress("")},
+			msgs: []string{"healthcheck server address cannot be empty"},
+		},
+		"unregistered adapter route": {
+			opts: []Option{
+				WithRouteStatusAdapter(
+					"/livez", func(_ healthcheck.Status) (int, string) {
+						return 200, "OK"
+					},
+				),
+			},
+			msgs: []string{
+				"healthcheck server status adapter route '/livez' is not registered",
+			},
+		},
+		"duplicate route": {
+			opts: []Option{WithHistoryRoute("/health")},
+			msgs: []string{
+				"healthcheck server route '/health' already registered",
+			},
+		},
+		"multiple": {
+			opts: []Option{
+				WithLogger(nil),
+				WithRoute("health"),
+				WithGroupRoute("/ready", ""),
+			},
+			msgs: []string{
+				"healthcheck server logger cannot be nil",
+				"healthcheck server route is invalid",
+				"healthcheck server route group cannot be empty",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				s, err := NewE(hc, tt.opts...)
+
+				if len(tt.msgs) == 0 {
+					assert.NoError(t, err)
+					assert.NotNil(t, s)
+					return
+				}
+
+				assert.Nil(t, s)
+				assert.ErrorIs(t, err, ErrInvalidOption)
+				for _, msg := range tt.msgs {
+					assert.ErrorContains(t, err, msg)
+				}
+			},
+		)
+	}
+}
+
+func TestServer_GroupRoute(t *testing.T) {
+	t.Parallel()
+
+	hc := healthcheck.New(
+		healthcheck.WithSimpleProbe(
+			"worker", func(_ context.Context) error {
+				return nil
+			},
+			healthcheck.ProbeGroups(healthcheck.GroupLiveness),
+		),
+		healthcheck.WithSimpleProbe(
+			"database", func(_ context.Context) error {
+				return errors.New("connection refused")
+			},
+			healthcheck.ProbeGroups(healthcheck.GroupReadiness),
+		),
+	)
+
+	get := serve(
+		t, hc,
+		WithGroupRoute("/livez", healthcheck.GroupLiveness),
+		WithGroupRoute("/readyz", healthcheck.GroupReadiness),
+	)
+
+	tests := map[string]struct {
+		target string
+		code   int
+		body   string
+	}{
+		"main route": {
+			target: "/health",
+			code:   http.StatusServiceUnavailable,
+			body:   "unhealthy",
+		},
+		"liveness": {
+			target: "/livez",
+			code:   http.StatusOK,
+			body:   "healthy",
+		},
+		"readiness": {
+			target: "/readyz",
+			code:   http.StatusServiceUnavailable,
+			body:   "unhealthy",
+		},
+		"unknown route": {
+			target: "/startupz",
+			code:   http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				resp := get(tt.target)
+				assert.Equal(t, tt.code, resp.code)
+				if tt.body != "" {
+					assert.Equal(t, tt.body, resp.body)
+				}
+			},
+		)
+	}
+}
+
+func TestServer_Starting(t *testing.T) {
+	t.Parallel()
+
+	hc := healthcheck.New(
+		healthcheck.WithStartupGracePeriod(time.Hour),
+		healthcheck.WithSimpleProbe(
+			"cache", func(_ context.Context) error {
+				return errors.New("warming up")
+			},
+		),
+	)
+
+	get := serve(
+		t, hc,
+		WithGroupRoute("/livez", healthcheck.GroupLiveness),
+		WithGroupRoute("/readyz", healthcheck.GroupReadiness),
+		WithGroupRoute("/startupz", healthcheck.GroupStartup),
+		WithGroupRoute("/custom", healthcheck.GroupLiveness),
+		WithRouteStatusAdapter(
+			"/custom", func(status healthcheck.Status) (int, string) {
+				return http.StatusTeapot, status.String()
+			},
+		),
+	)
+
+	tests := map[string]struct {
+		target string
+		code   int
+	}{
+		"main route": {
+			target: "/health",
+			code:   http.StatusServiceUnavailable,
+		},
+		"liveness": {
+			target: "/livez",
+			code:   http.StatusOK,
+		},
+		"readiness": {
+			target: "/readyz",
+			code:   http.StatusServiceUnavailable,
+		},
+		"startup": {
+			target: "/startupz",
+			code:   http.StatusServiceUnavailable,
+		},
+		"route adapter": {
+			target: "/custom",
+			code:   http.StatusTeapot,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				resp := get(tt.target)
+				assert.Equal(t, tt.code, resp.code)
+				assert.Equal(t, "starting", resp.body)
+			},
+		)
+	}
+}
+
+func TestServer_ReportRoute(t *testing.T) {
+	t.Parallel()
+
+	hc := healthcheck.New(
+		healthcheck.WithSimpleProbe(
+			"worker", func(_ context.Context) error {
+				return nil
+			},
+			healthcheck.ProbeGroups(healthcheck.GroupLiveness),
+		),
+		healthcheck.WithSimpleProbe(
+			"database", func(_ context.Context) error {
+				return errors.New("connection refused")
+			},
+			healthcheck.ProbeGroups(healthcheck.GroupReadiness),
+		),
+	)
+
+	get := serve(t, hc, WithReportRoute("/health/report"))
+
+	tests := map[string]struct {
+		target string
+		code   int
+		status healthcheck.Status
+		group  string
+		probes []string
+	}{
+		"all probes": {
+			target: "/health/report",
+			code:   http.StatusServiceUnavailable,
+			status: healthcheck.StatusUnhealthy,
+			probes: []string{"database", "worker"},
+		},
+		"liveness": {
+			target: "/health/report?group=liveness",
+			code:   http.StatusOK,
+			status: healthcheck.StatusHealthy,
+			group:  healthcheck.GroupLiveness,
+			probes: []string{"worker"},
+		},
+		"readiness": {
+			target: "/health/report?group=readiness",
+			code:   http.StatusServiceUnavailable,
+			status: healthcheck.StatusUnhealthy,
+			group:  healthcheck.GroupReadiness,
+			probes: []string{"database"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				resp := get(tt.target)
+				assert.Equal(t, tt.code, resp.code)
+				assert.Equal(t, "application/json", resp.contentType)
+
+				var report healthcheck.Report
+				assert.NoError(t, json.Unmarshal([]byte(resp.body), &report))
+				assert.Equal(t, tt.status, report.Status)
+				assert.Equal(t, tt.group, report.Group)
+				assert.ElementsMatch(
+					t, tt.probes, slices.Collect(maps.Keys(report.Probes)),
+				)
+			},
+		)
+	}
+}
+
+func TestServer_HistoryRoute(t *testing.T) {
+	t.Parallel()
+
+	hc := healthcheck.New(
+		healthcheck.WithHistorySize(2),
+		healthcheck.WithSimpleProbe(
+			"database", func(_ context.Context) error {
+				return errors.New("connection refused")
+			},
+		),
+	)
+
+	get := serve(t, hc, WithHistoryRoute("/health/history"))
+
+	assert.Equal(t, http.StatusServiceUnavailable, get("/health").code)
+
+	resp := get("/health/history")
+	assert.Equal(t, http.StatusOK, resp.code)
+	assert.Equal(t, "application/json", resp.contentType)
+
+	var history map[string][]struct {
+		Status healthcheck.Status `json:"status"`
+		Error  string             `json:"error"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(resp.body), &history))
+	if assert.Len(t, history["database"], 1) {
+		assert.Equal(
+			t, healthcheck.StatusUnhealthy, history["database"][0].Status,
+		)
+		assert.Equal(t, "connection refused", history["database"][0].Error)
+	}
+}
+
+func TestServer_Drain(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opts    []Option
+		drained string
+		kept    string
+	}{
+		"readiness route": {
+			opts: []Option{
+				WithGroupRoute("/livez", healthcheck.GroupLiveness),
+				WithGroupRoute("/readyz", healthcheck.GroupReadiness),
+			},
+			drained: healthcheck.GroupReadiness,
+			kept:    healthcheck.GroupLiveness,
+		},
+		"main route only": {
+			drained: "",
+		},
+		"drain group": {
+			opts: []Option{
+				WithGroupRoute("/livez", healthcheck.GroupLiveness),
+				WithGroupRoute("/ready", "ready"),
+				WithDrainGroup("ready"),
+			},
+			drained: "ready",
+			kept:    healthcheck.GroupLiveness,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				hc := healthcheck.New(
+					healthcheck.WithSimpleProbe(
+						"probe", func(_ context.Context) error {
+							return nil
+						},
+					),
+				)
+
+				s, get := start(t, hc, tt.opts...)
+				assert.NoError(t, get())
+
+				ctx := context.Background()
+				assert.NoError(t, s.Drain(ctx, time.Millisecond))
+
+				assert.Equal(
+					t, healthcheck.StatusUnhealthy,
+					hc.HandleGroup(ctx, tt.drained),
+				)
+				if tt.kept != "" {
+					assert.Equal(
+						t, healthcheck.StatusHealthy,
+						hc.HandleGroup(ctx, tt.kept),
+					)
+				}
+
+				assert.Error(t, get())
+			},
+		)
+	}
+}
+
+func TestServer_Drain_Canceled(t *testing.T) {
+	t.Parallel()
+
+	s, get := start(t, healthcheck.New())
+	assert.NoError(t, get())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, s.Drain(ctx, time.Minute), context.Canceled)
+	assert.Error(t, get())
+}
+
+// response is the part of a server response the tests check.
+type response struct {
+	code        int
+	body        string
+	contentType string
+}
+
+// serve creates a Server with the provided options
+// and returns a function making GET requests to it.
+func serve(
+	t *testing.T, hc *healthcheck.Healthcheck, opts ...Option,
+) func(target string) response {
+	t.Helper()
+
+	s := New(hc, opts...)
+
+	return func(target string) response {
+		rec := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(
+			rec, httptest.NewRequest(http.MethodGet, target, nil),
+		)
+
+		return response{
+			code:        rec.Code,
+			body:        rec.Body.String(),
+			contentType: rec.Header().Get("Content-Type"),
+		}
+	}
+}
+
+// start starts a Server with the provided options on a local listener
+// and returns it along with a function requesting its main route.
+func start(
+	t *testing.T, hc *healthcheck.Healthcheck, opts ...Option,
+) (*Server, func() error) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := New(hc, append(opts, WithListener(ln))...)
+	s.Start()
+	t.Cleanup(s.Stop)
+
+	client := &http.Client{
+		Transport: &http.Transport{DisableKeepAlives: true},
+	}
+
+	return s, func() error {
+		resp, err := client.Get("http://" + ln.Addr().String() + defaultRoute)
+		if err != nil {
+			return err
+		}
+
+		return resp.Body.Close()
+	}
+}
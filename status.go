@@ -1,5 +1,11 @@
 package healthcheck
 
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
 // Status represents a health status state in the system.
 type Status int
 
@@ -16,6 +22,10 @@ const (
 
 	// StatusUnhealthy represents an unhealthy status state in the system.
 	StatusUnhealthy
+
+	// StatusStarting represents a status state of the system which is still starting up,
+	// so its failures are not considered unhealthy yet.
+	StatusStarting
 )
 
 // Int converts the Status value to its corresponding integer representation.
@@ -32,7 +42,104 @@ func (s Status) String() string {
 		return "degraded"
 	case StatusUnhealthy:
 		return "unhealthy"
+	case StatusStarting:
+		return "starting"
 	default:
 		return "unknown"
 	}
 }
+
+// ParseStatus converts the string representation of a status to its Status value.
+// Returns an error wrapping ErrInvalidStatus if the string is not a known status.
+func ParseStatus(s string) (Status, error) {
+	switch s {
+	case "healthy":
+		return StatusHealthy, nil
+	case "degraded":
+		return StatusDegraded, nil
+	case "unhealthy":
+		return StatusUnhealthy, nil
+	case "starting":
+		return StatusStarting, nil
+	case "unknown":
+		return StatusUnknown, nil
+	default:
+		return StatusUnknown, fmt.Errorf("%w: %q", ErrInvalidStatus, s)
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// Returns an error wrapping ErrInvalidStatus if the status is not a known one.
+func (s Status) MarshalText() ([]byte, error) {
+	if !s.valid() {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidStatus, s.Int())
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Returns an error wrapping ErrInvalidStatus if the text is not a known status.
+func (s *Status) UnmarshalText(text []byte) error {
+	status, err := ParseStatus(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = status
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler encoding the status as a JSON string.
+// Returns an error wrapping ErrInvalidStatus if the status is not a known one.
+func (s Status) MarshalJSON() ([]byte, error) {
+	text, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler decoding the status from a JSON string.
+// Returns an error wrapping ErrInvalidStatus if the value is not a known status.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidStatus, data)
+	}
+
+	return s.UnmarshalText([]byte(text))
+}
+
+// LogValue implements slog.LogValuer logging the status as its string representation.
+func (s Status) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+func (s Status) valid() bool {
+	switch s {
+	case StatusUnknown, StatusHealthy, StatusDegraded, StatusUnhealthy, StatusStarting:
+		return true
+	default:
+		return false
+	}
+}
+
+// severity returns the rank of the status used for comparison,
+// the greater the worse.
+// Starting is considered worse than degraded, yet better than unhealthy.
+func (s Status) severity() int {
+	switch s {
+	case StatusHealthy:
+		return 1
+	case StatusDegraded:
+		return 2
+	case StatusStarting:
+		return 3
+	case StatusUnhealthy:
+		return 4
+	default:
+		return 0
+	}
+}
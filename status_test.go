@@ -1,6 +1,9 @@
 package healthcheck
 
 import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,6 +13,7 @@ func TestStatus_Int(t *testing.T) {
 	assert.Equal(t, int(StatusHealthy), StatusHealthy.Int())
 	assert.Equal(t, int(StatusDegraded), StatusDegraded.Int())
 	assert.Equal(t, int(StatusUnhealthy), StatusUnhealthy.Int())
+	assert.Equal(t, int(StatusStarting), StatusStarting.Int())
 }
 
 func TestStatus_String(t *testing.T) {
@@ -29,6 +33,10 @@ func TestStatus_String(t *testing.T) {
 			status: StatusUnhealthy,
 			want:   "unhealthy",
 		},
+		"starting": {
+			status: StatusStarting,
+			want:   "starting",
+		},
 		"unknown": {
 			status: StatusUnknown,
 			want:   "unknown",
@@ -47,3 +55,83 @@ func TestStatus_String(t *testing.T) {
 		)
 	}
 }
+
+func TestStatus_severity(t *testing.T) {
+	assert.Less(t, StatusUnknown.severity(), StatusHealthy.severity())
+	assert.Less(t, StatusHealthy.severity(), StatusDegraded.severity())
+	assert.Less(t, StatusDegraded.severity(), StatusStarting.severity())
+	assert.Less(t, StatusStarting.severity(), StatusUnhealthy.severity())
+	assert.Equal(t, StatusUnknown.severity(), Status(-2).severity())
+}
+
+func TestParseStatus(t *testing.T) {
+	for _, status := range []Status{
+		StatusUnknown,
+		StatusHealthy,
+		StatusDegraded,
+		StatusUnhealthy,
+		StatusStarting,
+	} {
+		got, err := ParseStatus(status.String())
+		assert.NoError(t, err)
+		assert.Equal(t, status, got)
+	}
+
+	for _, s := range []string{"", "Healthy", "ok", "0"} {
+		_, err := ParseStatus(s)
+		assert.ErrorIs(t, err, ErrInvalidStatus)
+	}
+}
+
+func TestStatus_MarshalText(t *testing.T) {
+	text, err := StatusDegraded.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "degraded", string(text))
+
+	_, err = Status(-2).MarshalText()
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+
+	var status Status
+	assert.NoError(t, status.UnmarshalText([]byte("unhealthy")))
+	assert.Equal(t, StatusUnhealthy, status)
+
+	assert.ErrorIs(t, status.UnmarshalText([]byte("broken")), ErrInvalidStatus)
+	assert.Equal(t, StatusUnhealthy, status)
+}
+
+func TestStatus_MarshalJSON(t *testing.T) {
+	type config struct {
+		Status   Status            `json:"status"`
+		Statuses map[Status]string `json:"statuses"`
+	}
+
+	in := config{
+		Status:   StatusStarting,
+		Statuses: map[Status]string{StatusHealthy: "ok"},
+	}
+
+	data, err := json.Marshal(in)
+	assert.NoError(t, err)
+	assert.JSONEq(
+		t, `{"status": "starting", "statuses": {"healthy": "ok"}}`, string(data),
+	)
+
+	var out config
+	assert.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, in, out)
+
+	_, err = json.Marshal(Status(5))
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+
+	var status Status
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"broken"`), &status), ErrInvalidStatus)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`1`), &status), ErrInvalidStatus)
+}
+
+func TestStatus_LogValue(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+
+	logger.Info("status", "status", StatusDegraded)
+	assert.Contains(t, buf.String(), `"status":"degraded"`)
+}